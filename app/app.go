@@ -11,7 +11,8 @@ import (
 	"github.com/tendermint/tendermint/libs/log"
 	tmtypes "github.com/tendermint/tendermint/types"
 
-	// bam "github.com/cosmos/cosmos-sdk/baseapp"
+	bam "github.com/cosmos/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/store"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
@@ -21,10 +22,10 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/params"
 	"github.com/cosmos/cosmos-sdk/x/slashing"
 	"github.com/cosmos/cosmos-sdk/x/stake"
-	bam "github.com/forbole/cosmos-sdk/baseapp"
 
 	"github.com/forbole/forboled/types"
 	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/sponsor"
 )
 
 const (
@@ -41,6 +42,7 @@ var (
 type ForboleApp struct {
 	*bam.BaseApp
 	cdc *wire.Codec
+	cms sdk.CommitMultiStore // committed state of the app queries
 
 	// keys to access the substores
 	keyMain          *sdk.KVStoreKey
@@ -49,6 +51,7 @@ type ForboleApp struct {
 	keyStake         *sdk.KVStoreKey
 	keySlashing      *sdk.KVStoreKey
 	keyContrib       *sdk.KVStoreKey
+	keySponsor       *sdk.KVStoreKey
 	keyGov           *sdk.KVStoreKey
 	keyRepute        *sdk.KVStoreKey
 	keyFeeCollection *sdk.KVStoreKey
@@ -58,13 +61,13 @@ type ForboleApp struct {
 	// Manage getting and setting accounts
 	accountMapper       auth.AccountMapper
 	feeCollectionKeeper auth.FeeCollectionKeeper
-	reputeAccountMapper auth.AccountMapper
 	coinKeeper          bank.Keeper
 	ibcMapper           ibc.Mapper
 	stakeKeeper         stake.Keeper
 	slashingKeeper      slashing.Keeper
 	govKeeper           gov.Keeper
 	contribKeeper       contrib.Keeper
+	sponsorKeeper       sponsor.Keeper
 	paramsKeeper        params.Keeper
 }
 
@@ -73,18 +76,23 @@ func NewForboleApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOp
 	// Create app-level codec for txs and accounts.
 	var cdc = MakeCodec()
 
-	bApp := bam.NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc), baseAppOptions...)
+	// The multistore is set before the options configure it.
+	cms := store.NewCommitMultiStore(db)
+	setCMS := func(bApp *bam.BaseApp) { bApp.SetCMS(cms) }
+	bApp := bam.NewBaseApp(appName, logger, db, auth.DefaultTxDecoder(cdc), append([]func(*bam.BaseApp){setCMS}, baseAppOptions...)...)
 	bApp.SetCommitMultiStoreTracer(traceStore)
 
 	var app = &ForboleApp{
 		BaseApp:          bApp,
 		cdc:              cdc,
+		cms:              cms,
 		keyMain:          sdk.NewKVStoreKey("main"),
 		keyAccount:       sdk.NewKVStoreKey("acc"),
 		keyIBC:           sdk.NewKVStoreKey("ibc"),
 		keyStake:         sdk.NewKVStoreKey("stake"),
 		keySlashing:      sdk.NewKVStoreKey("slashing"),
 		keyContrib:       sdk.NewKVStoreKey("contrib"),
+		keySponsor:       sdk.NewKVStoreKey("sponsor"),
 		keyGov:           sdk.NewKVStoreKey("gov"),
 		keyFeeCollection: sdk.NewKVStoreKey("fee"),
 		keyRepute:        sdk.NewKVStoreKey("repute"),
@@ -93,15 +101,11 @@ func NewForboleApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOp
 	}
 
 	// Define the accountMapper.
+	// The repute store is only kept mounted for migrating its legacy accounts.
 	app.accountMapper = auth.NewAccountMapper(
 		app.cdc,
-		app.keyAccount,        // target store
-		auth.ProtoBaseAccount, // prototype
-	)
-	app.reputeAccountMapper = auth.NewAccountMapper(
-		app.cdc,
-		app.keyRepute,            // target store
-		types.ProtoReputeAccount, // prototype //have to change here as well ????????????
+		app.keyAccount,           // target store
+		types.ProtoReputeAccount, // prototype
 	)
 
 	// Add handlers.
@@ -111,7 +115,8 @@ func NewForboleApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOp
 	app.stakeKeeper = stake.NewKeeper(app.cdc, app.keyStake, app.coinKeeper, app.RegisterCodespace(stake.DefaultCodespace))
 	app.govKeeper = gov.NewKeeper(app.cdc, app.keyGov, app.paramsKeeper.Setter(), app.coinKeeper, app.stakeKeeper, app.RegisterCodespace(gov.DefaultCodespace))
 	app.feeCollectionKeeper = auth.NewFeeCollectionKeeper(app.cdc, app.keyFeeCollection)
-	app.contribKeeper = contrib.NewKeeper(app.cdc, app.reputeAccountMapper, app.keyContrib)
+	app.contribKeeper = contrib.NewKeeper(app.cdc, app.accountMapper, app.coinKeeper, app.keyContrib, app.paramsKeeper.Getter())
+	app.sponsorKeeper = sponsor.NewKeeper(app.cdc, app.accountMapper, app.coinKeeper, app.keySponsor, app.RegisterCodespace(sponsor.DefaultCodespace))
 	app.slashingKeeper = slashing.NewKeeper(app.cdc, app.keySlashing, app.stakeKeeper, app.paramsKeeper.Getter(), app.RegisterCodespace(slashing.DefaultCodespace))
 	app.Router().
 		// AddRoute("auth", auth.NewHandler(app.accountMapper)).
@@ -119,6 +124,7 @@ func NewForboleApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOp
 		AddRoute("ibc", ibc.NewHandler(app.ibcMapper, app.coinKeeper)).
 		AddRoute("stake", stake.NewHandler(app.stakeKeeper)).
 		AddRoute("contrib", contrib.NewHandler(app.contribKeeper)).
+		AddRoute("sponsor", sponsor.NewHandler(app.sponsorKeeper)).
 		AddRoute("slashing", slashing.NewHandler(app.slashingKeeper)).
 		AddRoute("gov", gov.NewHandler(app.govKeeper))
 
@@ -126,10 +132,11 @@ func NewForboleApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOp
 	app.SetInitChainer(app.initChainer)
 	app.SetBeginBlocker(app.BeginBlocker)
 	app.SetEndBlocker(app.EndBlocker)
-	// app.SetAnteHandler(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))
-	// app.SetAnteHandler(auth.NewAnteHandler(app.reputeAccountMapper, app.feeCollectionKeeper))
-	app.SetAnteHandlers(auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper), auth.NewAnteHandler(app.reputeAccountMapper, app.feeCollectionKeeper))
-	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyContrib, app.keyRepute, app.keyParams)
+	app.SetAnteHandler(
+		contrib.NewAnteHandler(app.contribKeeper,
+			sponsor.NewAnteHandler(app.sponsorKeeper,
+				auth.NewAnteHandler(app.accountMapper, app.feeCollectionKeeper))))
+	app.MountStoresIAVL(app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov, app.keyFeeCollection, app.keyContrib, app.keySponsor, app.keyRepute, app.keyParams)
 	app.MountStore(app.tkeyParams, sdk.StoreTypeTransient)
 
 	err := app.LoadLatestVersion(app.keyMain)
@@ -140,6 +147,19 @@ func NewForboleApp(logger log.Logger, db dbm.DB, traceStore io.Writer, baseAppOp
 	return app
 }
 
+// Query answers the contrib feed queries from the latest committed state, the
+// other queries are answered by the BaseApp
+func (app *ForboleApp) Query(req abci.RequestQuery) abci.ResponseQuery {
+	if req.Path != contrib.QueryFeedPath {
+		return app.BaseApp.Query(req)
+	}
+	if req.Height != 0 && req.Height != app.LastBlockHeight() {
+		return sdk.ErrUnknownRequest("feeds are only queried at the latest height").QueryResult()
+	}
+	ctx := sdk.NewContext(app.cms.CacheMultiStore(), abci.Header{}, true, app.Logger)
+	return app.contribKeeper.QueryFeed(ctx, req)
+}
+
 // Custom tx codec
 func MakeCodec() *wire.Codec {
 	var cdc = wire.NewCodec()
@@ -151,6 +171,7 @@ func MakeCodec() *wire.Codec {
 	sdk.RegisterWire(cdc)
 	wire.RegisterCrypto(cdc)
 	contrib.RegisterWire(cdc)
+	sponsor.RegisterWire(cdc)
 	// auth.RegisterWire(cdc) //?needed?
 
 	// register custom AppAccount
@@ -163,6 +184,9 @@ func MakeCodec() *wire.Codec {
 
 // application updates every end block
 func (app *ForboleApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock) abci.ResponseBeginBlock {
+	app.migrateReputeAccounts(ctx)
+	contrib.BeginBlocker(ctx, app.contribKeeper)
+
 	tags := slashing.BeginBlocker(ctx, req, app.slashingKeeper)
 
 	return abci.ResponseBeginBlock{
@@ -173,6 +197,7 @@ func (app *ForboleApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBlock)
 // application updates every end block
 func (app *ForboleApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	tags := gov.EndBlocker(ctx, app.govKeeper)
+	tags = tags.AppendTags(contrib.EndBlocker(ctx, app.contribKeeper))
 	validatorUpdates := stake.EndBlocker(ctx, app.stakeKeeper)
 
 	// Add these new validators to the addr -> pubkey map.
@@ -203,12 +228,20 @@ func (app *ForboleApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) a
 		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	// admins without coins still get an account, otherwise only the role is set
 	for _, admin := range genesisState.Admins {
-		acc := admin.ToReputeAccount()
-		acc.AccountNumber = app.reputeAccountMapper.GetNextAccountNumber(ctx)
-		app.reputeAccountMapper.SetAccount(ctx, acc)
+		acc, ok := app.accountMapper.GetAccount(ctx, admin.Address).(*types.ReputeAccount)
+		if !ok {
+			acc = admin.ToReputeAccount()
+			acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		}
+		acc.SetRole(admin.Role)
+		app.accountMapper.SetAccount(ctx, acc)
 	}
 
+	// a new chain has no legacy repute accounts to migrate
+	markReputeAccountsMigrated(ctx, app.keyRepute)
+
 	// load the initial stake information
 	validators, err := stake.InitGenesis(ctx, app.stakeKeeper, genesisState.StakeData)
 	if err != nil {
@@ -221,6 +254,9 @@ func (app *ForboleApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) a
 
 	gov.InitGenesis(ctx, app.govKeeper, gov.DefaultGenesisState())
 
+	contrib.InitGenesis(ctx, app.contribKeeper, app.paramsKeeper.Setter(), genesisState.ContribData)
+	sponsor.InitGenesis(ctx, app.sponsorKeeper, genesisState.SponsorData)
+
 	return abci.ResponseInitChain{
 		Validators: validators,
 	}
@@ -252,7 +288,7 @@ func (app *ForboleApp) initChainer(ctx sdk.Context, req abci.RequestInitChain) a
 // 		}
 // 		return false
 // 	}
-// 	app.reputeAccountMapper.IterateAccounts(ctx, appendAdmin)
+// 	app.accountMapper.IterateAccounts(ctx, appendAdmin)
 
 // 	genState := GenesisState{
 // 		Accounts:  accounts,
@@ -278,8 +314,12 @@ func (app *ForboleApp) ExportAppStateAndValidators() (appState json.RawMessage,
 	// iterate to get the admins
 	admins := []GenesisAdmin{}
 	appendAdmin := func(acc auth.Account) (stop bool) {
-		role := acc.(*types.ReputeAccount).GetRole()
-		if role == "Admin" {
+		racc, ok := acc.(*types.ReputeAccount)
+		if !ok {
+			return false
+		}
+		role := racc.GetRole()
+		if role == types.AdminRole {
 			admin := GenesisAdmin{
 				Address: acc.GetAddress(),
 				Role:    role,
@@ -288,12 +328,14 @@ func (app *ForboleApp) ExportAppStateAndValidators() (appState json.RawMessage,
 		}
 		return false
 	}
-	app.reputeAccountMapper.IterateAccounts(ctx, appendAdmin)
+	app.accountMapper.IterateAccounts(ctx, appendAdmin)
 
 	genState := GenesisState{
-		Accounts:  accounts,
-		Admins:    admins,
-		StakeData: stake.WriteGenesis(ctx, app.stakeKeeper),
+		Accounts:    accounts,
+		Admins:      admins,
+		StakeData:   stake.WriteGenesis(ctx, app.stakeKeeper),
+		ContribData: contrib.WriteGenesis(ctx, app.contribKeeper),
+		SponsorData: sponsor.WriteGenesis(ctx, app.sponsorKeeper),
 	}
 	appState, err = wire.MarshalJSONIndent(app.cdc, genState)
 	if err != nil {
@@ -302,3 +344,31 @@ func (app *ForboleApp) ExportAppStateAndValidators() (appState json.RawMessage,
 	validators = stake.WriteValidators(ctx, app.stakeKeeper)
 	return appState, validators, nil
 }
+
+// LoadHeight switches the app to the state at height, for the tools reading
+// past states such as fbdebug
+func (app *ForboleApp) LoadHeight(height int64) error {
+	return app.LoadVersion(height, app.keyMain)
+}
+
+// StoreKeys returns the keys of the mounted stores by name
+func (app *ForboleApp) StoreKeys() map[string]*sdk.KVStoreKey {
+	keys := make(map[string]*sdk.KVStoreKey)
+	for _, key := range []*sdk.KVStoreKey{
+		app.keyMain, app.keyAccount, app.keyIBC, app.keyStake, app.keySlashing, app.keyGov,
+		app.keyFeeCollection, app.keyContrib, app.keySponsor, app.keyRepute, app.keyParams,
+	} {
+		keys[key.Name()] = key
+	}
+	return keys
+}
+
+// AccountMapper returns the mapper of the repute accounts
+func (app *ForboleApp) AccountMapper() auth.AccountMapper {
+	return app.accountMapper
+}
+
+// ContribKeeper returns the keeper of the contrib module
+func (app *ForboleApp) ContribKeeper() contrib.Keeper {
+	return app.contribKeeper
+}
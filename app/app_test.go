@@ -0,0 +1,53 @@
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/forbole/forboled/x/contrib"
+)
+
+func TestQueryFeed(t *testing.T) {
+	app := NewForboleApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	follower := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	author := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	now := time.Unix(1500000000, 0).UTC()
+
+	// write a follow and a post of the followee to the committed store
+	ms := app.cms.CacheMultiStore()
+	ctx := sdk.NewContext(ms, abci.Header{Time: now}, false, log.NewNopLogger())
+	for _, addr := range []sdk.AccAddress{follower, author} {
+		app.accountMapper.SetAccount(ctx, app.accountMapper.NewAccountWithAddress(ctx, addr))
+	}
+	for _, ctb := range []contrib.Contrib{
+		&contrib.Follow{BaseContrib2: contrib.BaseContrib2{BaseContrib: contrib.BaseContrib{Key: []byte("follow"), Contributor: follower, Time: now}, Recipient: author}},
+		&contrib.Post{BaseContrib2: contrib.BaseContrib2{BaseContrib: contrib.BaseContrib{Key: []byte("post"), Contributor: author, Time: now}, Recipient: follower}},
+	} {
+		tags := sdk.EmptyTags()
+		_, err := app.contribKeeper.UpdateContrib(ctx, ctb, &tags)
+		require.Nil(t, err)
+	}
+	ms.Write()
+
+	query := app.cdc.MustMarshalBinary(contrib.FeedQuery{Addr: follower})
+	res := app.Query(abci.RequestQuery{Path: contrib.QueryFeedPath, Data: query})
+	require.True(t, res.IsOK(), res.Log)
+	var posts []contrib.FeedPost
+	app.cdc.MustUnmarshalBinary(res.Value, &posts)
+	require.Equal(t, []contrib.FeedPost{{Author: author, Time: now, Key: []byte("post")}}, posts)
+
+	res = app.Query(abci.RequestQuery{Path: contrib.QueryFeedPath, Data: query, Height: 5})
+	require.False(t, res.IsOK())
+
+	// the other queries still reach the BaseApp
+	res = app.Query(abci.RequestQuery{Path: "/app/version"})
+	require.True(t, res.IsOK(), res.Log)
+}
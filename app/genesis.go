@@ -16,9 +16,12 @@ import (
 
 	"github.com/spf13/pflag"
 	"github.com/tendermint/tendermint/crypto"
+	cmn "github.com/tendermint/tendermint/libs/common"
 	tmtypes "github.com/tendermint/tendermint/types"
 
 	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/sponsor"
 )
 
 // DefaultKeyPass contains the default key password for genesis transactions
@@ -37,15 +40,22 @@ var (
 // State to Unmarshal
 type GenesisState struct {
 	// cosmos 0.18.0rc it's []*GenesisAccount
-	Accounts  []GenesisAccount   `json:"accounts"`
-	Admins    []GenesisAdmin     `json:"admins"`
-	StakeData stake.GenesisState `json:"stake"`
+	Accounts    []GenesisAccount     `json:"accounts"`
+	Admins      []GenesisAdmin       `json:"admins"`
+	StakeData   stake.GenesisState   `json:"stake"`
+	ContribData contrib.GenesisState `json:"contrib"`
+	SponsorData sponsor.GenesisState `json:"sponsor"`
 }
 
 // GenesisAccount doesn't need pubkey or sequence
 type GenesisAccount struct {
 	Address sdk.AccAddress `json:"address"`
 	Coins   sdk.Coins      `json:"coins"`
+	Name    string         `json:"name"`
+	Repute  int64          `json:"repute"`
+	Role    string         `json:"role"`
+	Bio     cmn.HexBytes   `json:"bio"`
+	Avatar  cmn.HexBytes   `json:"avatar"`
 }
 
 func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
@@ -56,18 +66,34 @@ func NewGenesisAccount(acc *auth.BaseAccount) GenesisAccount {
 }
 
 func NewGenesisAccountI(acc auth.Account) GenesisAccount {
-	return GenesisAccount{
+	gacc := GenesisAccount{
 		Address: acc.GetAddress(),
 		Coins:   acc.GetCoins(),
 	}
+	if racc, ok := acc.(*types.ReputeAccount); ok {
+		gacc.Name = racc.GetName()
+		gacc.Repute = racc.GetRepute()
+		gacc.Role = racc.GetRole()
+		gacc.Bio = racc.Bio
+		gacc.Avatar = racc.Avatar
+	}
+	return gacc
 }
 
-// convert GenesisAccount to auth.BaseAccount
-func (ga *GenesisAccount) ToAccount() (acc *auth.BaseAccount) {
-	return &auth.BaseAccount{
+// convert GenesisAccount to ReputeAccount
+func (ga *GenesisAccount) ToAccount() (acc *types.ReputeAccount) {
+	baseAcc := auth.BaseAccount{
 		Address: ga.Address,
 		Coins:   ga.Coins.Sort(),
 	}
+	return &types.ReputeAccount{
+		BaseAccount: baseAcc,
+		Name:        ga.Name,
+		Repute:      ga.Repute,
+		Role:        ga.Role,
+		Bio:         ga.Bio,
+		Avatar:      ga.Avatar,
+	}
 }
 
 // GenesisAdmin doesn't need pubkey or sequence
@@ -80,7 +106,7 @@ type GenesisAdmin struct {
 func NewGenesisAdmin(acc *auth.BaseAccount) GenesisAdmin {
 	return GenesisAdmin{
 		Address: acc.Address,
-		Role:    "Admin",
+		Role:    types.AdminRole,
 	}
 }
 
@@ -248,9 +274,11 @@ func ForboleAppGenState(cdc *wire.Codec, appGenTxs []json.RawMessage) (genesisSt
 
 	// create the final app state
 	genesisState = GenesisState{
-		Accounts:  genaccs,
-		Admins:    admins,
-		StakeData: stakeData,
+		Accounts:    genaccs,
+		Admins:      admins,
+		StakeData:   stakeData,
+		ContribData: contrib.DefaultGenesisState(),
+		SponsorData: sponsor.DefaultGenesisState(),
 	}
 	// appState, err = wire.MarshalJSONIndent(cdc, genesisState)
 	return
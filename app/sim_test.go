@@ -0,0 +1,147 @@
+package app
+
+import (
+	"encoding/json"
+	"flag"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	banksim "github.com/cosmos/cosmos-sdk/x/bank/simulation"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/cosmos/cosmos-sdk/x/stake"
+
+	"github.com/forbole/forboled/x/contrib"
+	contribsim "github.com/forbole/forboled/x/contrib/simulation"
+)
+
+var (
+	seed      int64
+	numBlocks int
+	blockSize int
+	verbose   bool
+)
+
+// A failing run prints its seed, run it again with
+// go test ./app -run TestFullForboleSimulation -SimulationSeed=<seed>
+func init() {
+	flag.Int64Var(&seed, "SimulationSeed", 42, "Simulation random seed")
+	flag.IntVar(&numBlocks, "SimulationNumBlocks", 50, "Number of blocks")
+	flag.IntVar(&blockSize, "SimulationBlockSize", 30, "Operations per block")
+	flag.BoolVar(&verbose, "SimulationVerbose", false, "Verbose log output")
+}
+
+// the coins every simulated account starts with
+var simGenesisCoins = sdk.Coins{sdk.NewInt64Coin("steak", 1000)}
+
+func appStateFn(r *rand.Rand, keys []crypto.PrivKey, accs []sdk.AccAddress) json.RawMessage {
+	var genesisAccounts []GenesisAccount
+	for _, acc := range accs {
+		genesisAccounts = append(genesisAccounts, GenesisAccount{
+			Address: acc,
+			Coins:   simGenesisCoins,
+		})
+	}
+
+	// no validators, the simulation is about the contribs
+	genesis := GenesisState{
+		Accounts:    genesisAccounts,
+		StakeData:   stake.DefaultGenesisState(),
+		ContribData: contrib.DefaultGenesisState(),
+	}
+
+	appState, err := MakeCodec().MarshalJSON(genesis)
+	if err != nil {
+		panic(err)
+	}
+	return appState
+}
+
+func testAndRunTxs(app *ForboleApp) []simulation.TestAndRunTx {
+	return contribsim.SimulateContribs(app.cdc, app.accountMapper)
+}
+
+func invariants(app *ForboleApp, numAccounts int) []simulation.Invariant {
+	total := sdk.Coins{}
+	for i := 0; i < numAccounts; i++ {
+		total = total.Plus(simGenesisCoins)
+	}
+
+	return []simulation.Invariant{
+		func(t *testing.T, baseapp *baseapp.BaseApp, log string) {
+			banksim.NonnegativeBalanceInvariant(app.accountMapper)(t, baseapp, log)
+			banksim.TotalCoinsInvariant(app.accountMapper, func() sdk.Coins { return total })(t, baseapp, log)
+			contribsim.AllInvariants(app.contribKeeper, app.accountMapper)(t, baseapp, log)
+		},
+	}
+}
+
+func newSimApp() *ForboleApp {
+	var logger log.Logger
+	if verbose {
+		logger = log.TestingLogger()
+	} else {
+		logger = log.NewNopLogger()
+	}
+	return NewForboleApp(logger, dbm.NewMemDB(), nil)
+}
+
+// TestFullForboleSimulation sends random contribs from random accounts
+// and checks the invariants after every block
+func TestFullForboleSimulation(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Forbole simulation")
+	}
+
+	app := newSimApp()
+	require.Equal(t, appName, app.Name())
+
+	// the simulation generates 250 accounts
+	simulation.SimulateFromSeed(
+		t, app.BaseApp, appStateFn, seed,
+		testAndRunTxs(app),
+		[]simulation.RandSetup{},
+		invariants(app, 250),
+		numBlocks,
+		blockSize,
+		true,
+	)
+}
+
+// TestAppStateDeterminism checks that a seed always leads to the same state
+func TestAppStateDeterminism(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping Forbole simulation")
+	}
+
+	numSeeds := 3
+	numTimesToRunPerSeed := 3
+	appHashList := make([]json.RawMessage, numTimesToRunPerSeed)
+
+	for i := 0; i < numSeeds; i++ {
+		seed := rand.Int63()
+		for j := 0; j < numTimesToRunPerSeed; j++ {
+			app := newSimApp()
+			simulation.SimulateFromSeed(
+				t, app.BaseApp, appStateFn, seed,
+				testAndRunTxs(app),
+				[]simulation.RandSetup{},
+				[]simulation.Invariant{},
+				20,
+				20,
+				true,
+			)
+			appHashList[j] = app.LastCommitID().Hash
+		}
+		for k := 1; k < numTimesToRunPerSeed; k++ {
+			require.Equal(t, appHashList[0], appHashList[k], "seed %d", seed)
+		}
+	}
+}
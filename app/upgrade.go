@@ -0,0 +1,97 @@
+package app
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/forbole/forboled/types"
+)
+
+// key in the legacy repute store marking that its accounts have been merged
+var reputeMigratedKey = []byte("migrated")
+
+func markReputeAccountsMigrated(ctx sdk.Context, keyRepute sdk.StoreKey) {
+	ctx.KVStore(keyRepute).Set(reputeMigratedKey, []byte{0x01})
+}
+
+// migrateReputeAccounts merges the accounts of the legacy "repute" store into
+// the "acc" store, so that every address has a single ReputeAccount with one
+// sequence. It runs in the first block after the upgrade and is a no-op
+// afterwards.
+func (app *ForboleApp) migrateReputeAccounts(ctx sdk.Context) {
+	reputeStore := ctx.KVStore(app.keyRepute)
+	if reputeStore.Has(reputeMigratedKey) {
+		return
+	}
+
+	// convert the plain accounts first, the iterator must not see our writes
+	var accs []auth.Account
+	app.accountMapper.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+		accs = append(accs, acc)
+		return false
+	})
+	for _, acc := range accs {
+		app.accountMapper.SetAccount(ctx, toReputeAccount(acc))
+	}
+
+	legacyMapper := auth.NewAccountMapper(app.cdc, app.keyRepute, types.ProtoReputeAccount)
+	var legacyAccs []auth.Account
+	legacyMapper.IterateAccounts(ctx, func(acc auth.Account) (stop bool) {
+		legacyAccs = append(legacyAccs, acc)
+		return false
+	})
+
+	for _, legacy := range legacyAccs {
+		lacc := toReputeAccount(legacy)
+		acc, ok := app.accountMapper.GetAccount(ctx, lacc.Address).(*types.ReputeAccount)
+		if !ok {
+			// only known to the repute store, e.g. invited users
+			acc = &types.ReputeAccount{BaseAccount: auth.BaseAccount{Address: lacc.Address}}
+			acc.AccountNumber = app.accountMapper.GetNextAccountNumber(ctx)
+		}
+		mergeReputeAccount(acc, lacc)
+		app.accountMapper.SetAccount(ctx, acc)
+		reputeStore.Delete(auth.AddressStoreKey(lacc.Address))
+	}
+
+	markReputeAccountsMigrated(ctx, app.keyRepute)
+	ctx.Logger().Info("Migrated repute accounts", "accounts", len(accs), "repute", len(legacyAccs))
+}
+
+// toReputeAccount wraps accounts stored before the upgrade
+func toReputeAccount(acc auth.Account) *types.ReputeAccount {
+	switch acc := acc.(type) {
+	case *types.ReputeAccount:
+		return acc
+	case *auth.BaseAccount:
+		return &types.ReputeAccount{BaseAccount: *acc}
+	default:
+		return &types.ReputeAccount{BaseAccount: auth.BaseAccount{
+			Address:       acc.GetAddress(),
+			Coins:         acc.GetCoins(),
+			PubKey:        acc.GetPubKey(),
+			AccountNumber: acc.GetAccountNumber(),
+			Sequence:      acc.GetSequence(),
+		}}
+	}
+}
+
+// mergeReputeAccount copies the repute data of a legacy account into acc.
+// The higher sequence is kept so that txs signed against either store
+// cannot be replayed, and the coins of both accounts are kept.
+func mergeReputeAccount(acc, legacy *types.ReputeAccount) {
+	acc.Repute += legacy.Repute
+	acc.Coins = acc.Coins.Plus(legacy.Coins)
+	if acc.Name == "" {
+		acc.Name = legacy.Name
+	}
+	if acc.Role == "" {
+		acc.Role = legacy.Role
+	}
+	if acc.PubKey == nil {
+		acc.PubKey = legacy.PubKey
+	}
+	if legacy.Sequence > acc.Sequence {
+		acc.Sequence = legacy.Sequence
+	}
+}
@@ -0,0 +1,84 @@
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/forbole/forboled/types"
+)
+
+func TestMigrateReputeAccounts(t *testing.T) {
+	app := NewForboleApp(log.NewNopLogger(), dbm.NewMemDB(), nil)
+	ctx := app.NewContext(true, abci.Header{})
+	legacyMapper := auth.NewAccountMapper(app.cdc, app.keyRepute, types.ProtoReputeAccount)
+
+	pubKey := ed25519.GenPrivKey().PubKey()
+	merged := sdk.AccAddress(pubKey.Address())
+	plain := sdk.AccAddress([]byte("plain"))
+	legacyOnly := sdk.AccAddress([]byte("legacyOnly"))
+
+	// accounts stored before the upgrade, in the acc and repute stores
+	app.accountMapper.SetAccount(ctx, &auth.BaseAccount{
+		Address:       merged,
+		Coins:         sdk.Coins{sdk.NewInt64Coin("fbc", 10)},
+		AccountNumber: app.accountMapper.GetNextAccountNumber(ctx),
+		Sequence:      2,
+	})
+	app.accountMapper.SetAccount(ctx, &auth.BaseAccount{
+		Address:       plain,
+		AccountNumber: app.accountMapper.GetNextAccountNumber(ctx),
+		Sequence:      7,
+	})
+	legacyMapper.SetAccount(ctx, &types.ReputeAccount{
+		BaseAccount: auth.BaseAccount{Address: merged, Coins: sdk.Coins{sdk.NewInt64Coin("fbc", 3)}, PubKey: pubKey, Sequence: 4},
+		Name:        "alice",
+		Repute:      5,
+		Role:        "Admin",
+	})
+	legacyMapper.SetAccount(ctx, &types.ReputeAccount{
+		BaseAccount: auth.BaseAccount{Address: legacyOnly, Sequence: 1},
+		Repute:      8,
+	})
+
+	app.migrateReputeAccounts(ctx)
+
+	cases := []struct {
+		name string
+		addr sdk.AccAddress
+		acc  types.ReputeAccount
+	}{
+		{"merged", merged, types.ReputeAccount{
+			BaseAccount: auth.BaseAccount{Address: merged, Coins: sdk.Coins{sdk.NewInt64Coin("fbc", 13)}, PubKey: pubKey, AccountNumber: 0, Sequence: 4},
+			Name:        "alice",
+			Repute:      5,
+			Role:        "Admin",
+		}},
+		{"only in the acc store", plain, types.ReputeAccount{
+			BaseAccount: auth.BaseAccount{Address: plain, AccountNumber: 1, Sequence: 7},
+		}},
+		{"only in the repute store gets a fresh account number", legacyOnly, types.ReputeAccount{
+			BaseAccount: auth.BaseAccount{Address: legacyOnly, AccountNumber: 2, Sequence: 1},
+			Repute:      8,
+		}},
+	}
+	for _, tc := range cases {
+		acc, ok := app.accountMapper.GetAccount(ctx, tc.addr).(*types.ReputeAccount)
+		require.True(t, ok, tc.name)
+		require.Equal(t, tc.acc, *acc, tc.name)
+		require.Nil(t, legacyMapper.GetAccount(ctx, tc.addr), tc.name)
+	}
+
+	// the migration only runs once
+	legacyMapper.SetAccount(ctx, &types.ReputeAccount{BaseAccount: auth.BaseAccount{Address: merged}, Repute: 1})
+	app.migrateReputeAccounts(ctx)
+	require.Equal(t, int64(5), app.accountMapper.GetAccount(ctx, merged).(*types.ReputeAccount).Repute)
+	require.Equal(t, int64(3), app.accountMapper.GetNextAccountNumber(ctx))
+}
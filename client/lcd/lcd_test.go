@@ -1,667 +1,317 @@
 package lcd
 
-// import (
-// 	"bytes"
-// 	"encoding/hex"
-// 	"encoding/json"
-// 	"fmt"
-// 	"io/ioutil"
-// 	"net"
-// 	"net/http"
-// 	"os"
-// 	"regexp"
-// 	"testing"
-
-// 	"github.com/spf13/viper"
-// 	"github.com/stretchr/testify/assert"
-// 	"github.com/stretchr/testify/require"
-
-// 	abci "github.com/tendermint/abci/types"
-// 	crypto "github.com/tendermint/go-crypto"
-// 	cryptoKeys "github.com/tendermint/go-crypto/keys"
-// 	tmcfg "github.com/tendermint/tendermint/config"
-// 	nm "github.com/tendermint/tendermint/node"
-// 	p2p "github.com/tendermint/tendermint/p2p"
-// 	pvm "github.com/tendermint/tendermint/privval"
-// 	"github.com/tendermint/tendermint/proxy"
-// 	ctypes "github.com/tendermint/tendermint/rpc/core/types"
-// 	tmrpc "github.com/tendermint/tendermint/rpc/lib/server"
-// 	tmtypes "github.com/tendermint/tendermint/types"
-// 	"github.com/tendermint/tmlibs/cli"
-// 	dbm "github.com/tendermint/tmlibs/db"
-// 	"github.com/tendermint/tmlibs/log"
-
-// 	client "github.com/cosmos/cosmos-sdk/client"
-// 	keys "github.com/cosmos/cosmos-sdk/client/keys"
-// 	"github.com/cosmos/cosmos-sdk/server"
-// 	tests "github.com/cosmos/cosmos-sdk/tests"
-// 	sdk "github.com/cosmos/cosmos-sdk/types"
-// 	"github.com/cosmos/cosmos-sdk/wire"
-// 	"github.com/cosmos/cosmos-sdk/x/auth"
-// 	"github.com/cosmos/cosmos-sdk/x/stake"
-// 	gapp "github.com/forbole/forboled/app"
-// )
-
-// var (
-// 	coinDenom  = "steak"
-// 	coinAmount = int64(10000000)
-
-// 	validatorAddr1 = ""
-// 	validatorAddr2 = ""
-
-// 	// XXX bad globals
-// 	name     = "test"
-// 	password = "0123456789"
-// 	port     string
-// 	seed     string
-// 	sendAddr string
-// )
-
-// func TestKeys(t *testing.T) {
-
-// 	// empty keys
-// 	// XXX: the test comes with a key setup
-// 	/*
-// 		res, body := request(t, port, "GET", "/keys", nil)
-// 		require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 		assert.Equal(t, "[]", body, "Expected an empty array")
-// 	*/
-
-// 	// get seed
-// 	res, body := request(t, port, "GET", "/keys/seed", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	newSeed := body
-// 	reg, err := regexp.Compile(`([a-z]+ ){12}`)
-// 	require.Nil(t, err)
-// 	match := reg.MatchString(seed)
-// 	assert.True(t, match, "Returned seed has wrong foramt", seed)
-
-// 	newName := "test_newname"
-// 	newPassword := "0987654321"
-
-// 	// add key
-// 	var jsonStr = []byte(fmt.Sprintf(`{"name":"test_fail", "password":"%s"}`, password))
-// 	res, body = request(t, port, "POST", "/keys", jsonStr)
-
-// 	assert.Equal(t, http.StatusBadRequest, res.StatusCode, "Account creation should require a seed")
-
-// 	jsonStr = []byte(fmt.Sprintf(`{"name":"%s", "password":"%s", "seed": "%s"}`, newName, newPassword, newSeed))
-// 	res, body = request(t, port, "POST", "/keys", jsonStr)
-
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	addr := body
-// 	assert.Len(t, addr, 40, "Returned address has wrong format", addr)
-
-// 	// existing keys
-// 	res, body = request(t, port, "GET", "/keys", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	var m [2]keys.KeyOutput
-// 	err = cdc.UnmarshalJSON([]byte(body), &m)
-// 	require.Nil(t, err)
-
-// 	sendAddrAcc, _ := sdk.GetAccAddressHex(sendAddr)
-// 	addrAcc, _ := sdk.GetAccAddressHex(addr)
-
-// 	assert.Equal(t, m[0].Name, name, "Did not serve keys name correctly")
-// 	assert.Equal(t, m[0].Address, sendAddrAcc, "Did not serve keys Address correctly")
-// 	assert.Equal(t, m[1].Name, newName, "Did not serve keys name correctly")
-// 	assert.Equal(t, m[1].Address, addrAcc, "Did not serve keys Address correctly")
-
-// 	// select key
-// 	keyEndpoint := fmt.Sprintf("/keys/%s", newName)
-// 	res, body = request(t, port, "GET", keyEndpoint, nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	var m2 keys.KeyOutput
-// 	err = cdc.UnmarshalJSON([]byte(body), &m2)
-// 	require.Nil(t, err)
-
-// 	assert.Equal(t, newName, m2.Name, "Did not serve keys name correctly")
-// 	assert.Equal(t, addrAcc, m2.Address, "Did not serve keys Address correctly")
-
-// 	// update key
-// 	jsonStr = []byte(fmt.Sprintf(`{"old_password":"%s", "new_password":"12345678901"}`, newPassword))
-// 	res, body = request(t, port, "PUT", keyEndpoint, jsonStr)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	// here it should say unauthorized as we changed the password before
-// 	res, body = request(t, port, "PUT", keyEndpoint, jsonStr)
-// 	require.Equal(t, http.StatusUnauthorized, res.StatusCode, body)
-
-// 	// delete key
-// 	jsonStr = []byte(`{"password":"12345678901"}`)
-// 	res, body = request(t, port, "DELETE", keyEndpoint, jsonStr)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// }
-
-// func TestVersion(t *testing.T) {
-
-// 	// node info
-// 	res, body := request(t, port, "GET", "/version", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	reg, err := regexp.Compile(`\d+\.\d+\.\d+(-dev)?`)
-// 	require.Nil(t, err)
-// 	match := reg.MatchString(body)
-// 	assert.True(t, match, body)
-// }
-
-// func TestNodeStatus(t *testing.T) {
-
-// 	// node info
-// 	res, body := request(t, port, "GET", "/node_info", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	var nodeInfo p2p.NodeInfo
-// 	err := cdc.UnmarshalJSON([]byte(body), &nodeInfo)
-// 	require.Nil(t, err, "Couldn't parse node info")
-
-// 	assert.NotEqual(t, p2p.NodeInfo{}, nodeInfo, "res: %v", res)
-
-// 	// syncing
-// 	res, body = request(t, port, "GET", "/syncing", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	// we expect that there is no other node running so the syncing state is "false"
-// 	// we c
-// 	assert.Equal(t, "false", body)
-// }
-
-// func TestBlock(t *testing.T) {
-
-// 	tests.WaitForHeight(2, port)
-
-// 	var resultBlock ctypes.ResultBlock
-
-// 	res, body := request(t, port, "GET", "/blocks/latest", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	err := cdc.UnmarshalJSON([]byte(body), &resultBlock)
-// 	require.Nil(t, err, "Couldn't parse block")
-
-// 	assert.NotEqual(t, ctypes.ResultBlock{}, resultBlock)
-
-// 	// --
-
-// 	res, body = request(t, port, "GET", "/blocks/1", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	err = json.Unmarshal([]byte(body), &resultBlock)
-// 	require.Nil(t, err, "Couldn't parse block")
-
-// 	assert.NotEqual(t, ctypes.ResultBlock{}, resultBlock)
-
-// 	// --
-
-// 	res, body = request(t, port, "GET", "/blocks/1000000000", nil)
-// 	require.Equal(t, http.StatusNotFound, res.StatusCode, body)
-// }
-
-// func TestValidators(t *testing.T) {
-
-// 	var resultVals ctypes.ResultValidators
-
-// 	res, body := request(t, port, "GET", "/validatorsets/latest", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	err := cdc.UnmarshalJSON([]byte(body), &resultVals)
-// 	require.Nil(t, err, "Couldn't parse validatorset")
-
-// 	assert.NotEqual(t, ctypes.ResultValidators{}, resultVals)
-
-// 	// --
-
-// 	res, body = request(t, port, "GET", "/validatorsets/1", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	err = cdc.UnmarshalJSON([]byte(body), &resultVals)
-// 	require.Nil(t, err, "Couldn't parse validatorset")
-
-// 	assert.NotEqual(t, ctypes.ResultValidators{}, resultVals)
-
-// 	// --
-
-// 	res, body = request(t, port, "GET", "/validatorsets/1000000000", nil)
-// 	require.Equal(t, http.StatusNotFound, res.StatusCode)
-// }
-
-// func TestCoinSend(t *testing.T) {
-
-// 	// query empty
-// 	//res, body := request(t, port, "GET", "/accounts/8FA6AB57AD6870F6B5B2E57735F38F2F30E73CB6", nil)
-// 	res, body := request(t, port, "GET", "/accounts/8FA6AB57AD6870F6B5B2E57735F38F2F30E73CB6", nil)
-// 	require.Equal(t, http.StatusNoContent, res.StatusCode, body)
-
-// 	acc := getAccount(t, sendAddr)
-// 	initialBalance := acc.GetCoins()
-
-// 	// create TX
-// 	receiveAddr, resultTx := doSend(t, port, seed)
-// 	tests.WaitForHeight(resultTx.Height+1, port)
-
-// 	// check if tx was commited
-// 	assert.Equal(t, uint32(0), resultTx.CheckTx.Code)
-// 	assert.Equal(t, uint32(0), resultTx.DeliverTx.Code)
-
-// 	// query sender
-// 	acc = getAccount(t, sendAddr)
-// 	coins := acc.GetCoins()
-// 	mycoins := coins[0]
-// 	assert.Equal(t, coinDenom, mycoins.Denom)
-// 	assert.Equal(t, initialBalance[0].Amount-1, mycoins.Amount)
-
-// 	// query receiver
-// 	acc = getAccount(t, receiveAddr)
-// 	coins = acc.GetCoins()
-// 	mycoins = coins[0]
-// 	assert.Equal(t, coinDenom, mycoins.Denom)
-// 	assert.Equal(t, int64(1), mycoins.Amount)
-// }
-
-// func TestIBCTransfer(t *testing.T) {
-
-// 	acc := getAccount(t, sendAddr)
-// 	initialBalance := acc.GetCoins()
-
-// 	// create TX
-// 	resultTx := doIBCTransfer(t, port, seed)
-
-// 	tests.WaitForHeight(resultTx.Height+1, port)
-
-// 	// check if tx was commited
-// 	assert.Equal(t, uint32(0), resultTx.CheckTx.Code)
-// 	assert.Equal(t, uint32(0), resultTx.DeliverTx.Code)
-
-// 	// query sender
-// 	acc = getAccount(t, sendAddr)
-// 	coins := acc.GetCoins()
-// 	mycoins := coins[0]
-// 	assert.Equal(t, coinDenom, mycoins.Denom)
-// 	assert.Equal(t, initialBalance[0].Amount-1, mycoins.Amount)
-
-// 	// TODO: query ibc egress packet state
-// }
-
-// func TestTxs(t *testing.T) {
-
-// 	// TODO: re-enable once we can get txs by tag
-
-// 	// query wrong
-// 	// res, body := request(t, port, "GET", "/txs", nil)
-// 	// require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
-
-// 	// query empty
-// 	// res, body = request(t, port, "GET", fmt.Sprintf("/txs?tag=coin.sender='%s'", "8FA6AB57AD6870F6B5B2E57735F38F2F30E73CB6"), nil)
-// 	// require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	// assert.Equal(t, "[]", body)
-
-// 	// create TX
-// 	_, resultTx := doSend(t, port, seed)
-
-// 	tests.WaitForHeight(resultTx.Height+1, port)
-
-// 	// check if tx is findable
-// 	res, body := request(t, port, "GET", fmt.Sprintf("/txs/%s", resultTx.Hash), nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	// // query sender
-// 	// res, body = request(t, port, "GET", fmt.Sprintf("/txs?tag=coin.sender='%s'", addr), nil)
-// 	// require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	// assert.NotEqual(t, "[]", body)
-
-// 	// // query receiver
-// 	// res, body = request(t, port, "GET", fmt.Sprintf("/txs?tag=coin.receiver='%s'", receiveAddr), nil)
-// 	// require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	// assert.NotEqual(t, "[]", body)
-// }
-
-// func TestValidatorsQuery(t *testing.T) {
-// 	validators := getValidators(t)
-// 	assert.Equal(t, len(validators), 2)
-
-// 	// make sure all the validators were found (order unknown because sorted by owner addr)
-// 	foundVal1, foundVal2 := false, false
-// 	res1, res2 := hex.EncodeToString(validators[0].Owner), hex.EncodeToString(validators[1].Owner)
-// 	if res1 == validatorAddr1 || res2 == validatorAddr1 {
-// 		foundVal1 = true
-// 	}
-// 	if res1 == validatorAddr2 || res2 == validatorAddr2 {
-// 		foundVal2 = true
-// 	}
-// 	assert.True(t, foundVal1, "validatorAddr1 %v, res1 %v, res2 %v", validatorAddr1, res1, res2)
-// 	assert.True(t, foundVal2, "validatorAddr2 %v, res1 %v, res2 %v", validatorAddr2, res1, res2)
-// }
-
-// func TestBond(t *testing.T) {
-
-// 	// create bond TX
-// 	resultTx := doBond(t, port, seed)
-// 	tests.WaitForHeight(resultTx.Height+1, port)
-
-// 	// check if tx was commited
-// 	assert.Equal(t, uint32(0), resultTx.CheckTx.Code)
-// 	assert.Equal(t, uint32(0), resultTx.DeliverTx.Code)
-
-// 	// query sender
-// 	acc := getAccount(t, sendAddr)
-// 	coins := acc.GetCoins()
-// 	assert.Equal(t, int64(87), coins.AmountOf(coinDenom))
-
-// 	// query candidate
-// 	bond := getDelegation(t, sendAddr, validatorAddr1)
-// 	assert.Equal(t, "10/1", bond.Shares.String())
-// }
-
-// func TestUnbond(t *testing.T) {
-
-// 	// create unbond TX
-// 	resultTx := doUnbond(t, port, seed)
-// 	tests.WaitForHeight(resultTx.Height+1, port)
-
-// 	// check if tx was commited
-// 	assert.Equal(t, uint32(0), resultTx.CheckTx.Code)
-// 	assert.Equal(t, uint32(0), resultTx.DeliverTx.Code)
-
-// 	// query sender
-// 	acc := getAccount(t, sendAddr)
-// 	coins := acc.GetCoins()
-// 	assert.Equal(t, int64(98), coins.AmountOf(coinDenom))
-
-// 	// query candidate
-// 	bond := getDelegation(t, sendAddr, validatorAddr1)
-// 	assert.Equal(t, "9/1", bond.Shares.String())
-// }
-
-// //__________________________________________________________
-// // helpers
-
-// // strt TM and the LCD in process, listening on their respective sockets
-// func startTMAndLCD() (*nm.Node, net.Listener, error) {
-
-// 	dir, err := ioutil.TempDir("", "lcd_test")
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-// 	viper.Set(cli.HomeFlag, dir)
-// 	viper.Set(client.FlagGas, 200000)
-// 	kb, err := keys.GetKeyBase() // dbm.NewMemDB()) // :(
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-
-// 	config := GetConfig()
-// 	config.Consensus.TimeoutCommit = 1000
-// 	config.Consensus.SkipTimeoutCommit = false
-
-// 	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
-// 	logger = log.NewFilter(logger, log.AllowError())
-// 	privValidatorFile := config.PrivValidatorFile()
-// 	privVal := pvm.LoadOrGenFilePV(privValidatorFile)
-// 	db := dbm.NewMemDB()
-// 	app := gapp.NewForboleApp(logger, db)
-// 	cdc = gapp.MakeCodec() // XXX
-
-// 	genesisFile := config.GenesisFile()
-// 	genDoc, err := tmtypes.GenesisDocFromFile(genesisFile)
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-
-// 	genDoc.Validators = append(genDoc.Validators,
-// 		tmtypes.GenesisValidator{
-// 			PubKey: crypto.GenPrivKeyEd25519().PubKey(),
-// 			Power:  1,
-// 			Name:   "val",
-// 		},
-// 	)
-
-// 	pk1 := genDoc.Validators[0].PubKey
-// 	pk2 := genDoc.Validators[1].PubKey
-// 	validatorAddr1 = hex.EncodeToString(pk1.Address())
-// 	validatorAddr2 = hex.EncodeToString(pk2.Address())
-
-// 	// NOTE it's bad practice to reuse pk address for the owner address but doing in the
-// 	// test for simplicity
-// 	var appGenTxs [2]json.RawMessage
-// 	appGenTxs[0], _, _, err = gapp.ForboleAppGenTxNF(cdc, pk1, pk1.Address(), "test_val1", true)
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-// 	appGenTxs[1], _, _, err = gapp.ForboleAppGenTxNF(cdc, pk2, pk2.Address(), "test_val2", true)
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-
-// 	genesisState, err := gapp.ForboleAppGenState(cdc, appGenTxs[:])
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-
-// 	// add the sendAddr to genesis
-// 	var info cryptoKeys.Info
-// 	info, seed, err = kb.Create(name, password, cryptoKeys.AlgoEd25519) // XXX global seed
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-// 	sendAddr = info.PubKey.Address().String() // XXX global
-// 	accAuth := auth.NewBaseAccountWithAddress(info.PubKey.Address())
-// 	accAuth.Coins = sdk.Coins{{"steak", 100}}
-// 	acc := gapp.NewGenesisAccount(&accAuth)
-// 	genesisState.Accounts = append(genesisState.Accounts, acc)
-
-// 	appState, err := wire.MarshalJSONIndent(cdc, genesisState)
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-// 	genDoc.AppStateJSON = appState
-
-// 	// LCD listen address
-// 	var listenAddr string
-// 	listenAddr, port, err = server.FreeTCPAddr()
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-
-// 	// XXX: need to set this so LCD knows the tendermint node address!
-// 	viper.Set(client.FlagNode, config.RPC.ListenAddress)
-// 	viper.Set(client.FlagChainID, genDoc.ChainID)
-
-// 	node, err := startTM(config, logger, genDoc, privVal, app)
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-// 	lcd, err := startLCD(logger, listenAddr, cdc)
-// 	if err != nil {
-// 		return nil, nil, err
-// 	}
-
-// 	tests.WaitForStart(port)
-
-// 	return node, lcd, nil
-// }
-
-// // Create & start in-process tendermint node with memdb
-// // and in-process abci application.
-// // TODO: need to clean up the WAL dir or enable it to be not persistent
-// func startTM(cfg *tmcfg.Config, logger log.Logger, genDoc *tmtypes.GenesisDoc, privVal tmtypes.PrivValidator, app abci.Application) (*nm.Node, error) {
-// 	genDocProvider := func() (*tmtypes.GenesisDoc, error) { return genDoc, nil }
-// 	dbProvider := func(*nm.DBContext) (dbm.DB, error) { return dbm.NewMemDB(), nil }
-// 	n, err := nm.NewNode(cfg,
-// 		privVal,
-// 		proxy.NewLocalClientCreator(app),
-// 		genDocProvider,
-// 		dbProvider,
-// 		logger.With("module", "node"))
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	err = n.Start()
-// 	if err != nil {
-// 		return nil, err
-// 	}
-
-// 	// wait for rpc
-// 	tests.WaitForRPC(GetConfig().RPC.ListenAddress)
-
-// 	logger.Info("Tendermint running!")
-// 	return n, err
-// }
-
-// // start the LCD. note this blocks!
-// func startLCD(logger log.Logger, listenAddr string, cdc *wire.Codec) (net.Listener, error) {
-// 	handler := createHandler(cdc)
-// 	return tmrpc.StartHTTPServer(listenAddr, handler, logger)
-// }
-
-// func request(t *testing.T, port, method, path string, payload []byte) (*http.Response, string) {
-// 	var res *http.Response
-// 	var err error
-// 	url := fmt.Sprintf("http://localhost:%v%v", port, path)
-// 	req, err := http.NewRequest(method, url, bytes.NewBuffer(payload))
-// 	require.Nil(t, err)
-// 	res, err = http.DefaultClient.Do(req)
-// 	//	res, err = http.Post(url, "application/json", bytes.NewBuffer(payload))
-// 	require.Nil(t, err)
-
-// 	output, err := ioutil.ReadAll(res.Body)
-// 	res.Body.Close()
-// 	require.Nil(t, err)
-
-// 	return res, string(output)
-// }
-
-// func getAccount(t *testing.T, sendAddr string) auth.Account {
-// 	// get the account to get the sequence
-// 	res, body := request(t, port, "GET", "/accounts/"+sendAddr, nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	var acc auth.Account
-// 	err := cdc.UnmarshalJSON([]byte(body), &acc)
-// 	require.Nil(t, err)
-// 	return acc
-// }
-
-// func doSend(t *testing.T, port, seed string) (receiveAddr string, resultTx ctypes.ResultBroadcastTxCommit) {
-
-// 	// create receive address
-// 	kb := client.MockKeyBase()
-// 	receiveInfo, _, err := kb.Create("receive_address", "1234567890", cryptoKeys.CryptoAlgo("ed25519"))
-// 	require.Nil(t, err)
-// 	receiveAddr = receiveInfo.PubKey.Address().String()
-
-// 	acc := getAccount(t, sendAddr)
-// 	sequence := acc.GetSequence()
-
-// 	// send
-// 	jsonStr := []byte(fmt.Sprintf(`{ "name":"%s", "password":"%s", "sequence":%d, "amount":[{ "denom": "%s", "amount": 1 }] }`, name, password, sequence, coinDenom))
-// 	res, body := request(t, port, "POST", "/accounts/"+receiveAddr+"/send", jsonStr)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	err = cdc.UnmarshalJSON([]byte(body), &resultTx)
-// 	require.Nil(t, err)
-
-// 	return receiveAddr, resultTx
-// }
-
-// func doIBCTransfer(t *testing.T, port, seed string) (resultTx ctypes.ResultBroadcastTxCommit) {
-
-// 	// create receive address
-// 	kb := client.MockKeyBase()
-// 	receiveInfo, _, err := kb.Create("receive_address", "1234567890", cryptoKeys.CryptoAlgo("ed25519"))
-// 	require.Nil(t, err)
-// 	receiveAddr := receiveInfo.PubKey.Address().String()
-
-// 	// get the account to get the sequence
-// 	acc := getAccount(t, sendAddr)
-// 	sequence := acc.GetSequence()
-
-// 	// send
-// 	jsonStr := []byte(fmt.Sprintf(`{ "name":"%s", "password":"%s", "sequence":%d, "amount":[{ "denom": "%s", "amount": 1 }] }`, name, password, sequence, coinDenom))
-// 	res, body := request(t, port, "POST", "/ibc/testchain/"+receiveAddr+"/send", jsonStr)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	err = cdc.UnmarshalJSON([]byte(body), &resultTx)
-// 	require.Nil(t, err)
-
-// 	return resultTx
-// }
-
-// func getDelegation(t *testing.T, delegatorAddr, candidateAddr string) stake.Delegation {
-// 	// get the account to get the sequence
-// 	res, body := request(t, port, "GET", "/stake/"+delegatorAddr+"/bonding_status/"+candidateAddr, nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	var bond stake.Delegation
-// 	err := cdc.UnmarshalJSON([]byte(body), &bond)
-// 	require.Nil(t, err)
-// 	return bond
-// }
-
-// func doBond(t *testing.T, port, seed string) (resultTx ctypes.ResultBroadcastTxCommit) {
-// 	// get the account to get the sequence
-// 	acc := getAccount(t, sendAddr)
-// 	sequence := acc.GetSequence()
-
-// 	// send
-// 	jsonStr := []byte(fmt.Sprintf(`{
-// 		"name": "%s",
-// 		"password": "%s",
-// 		"sequence": %d,
-// 		"delegate": [
-// 			{
-// 				"delegator_addr": "%x",
-// 				"validator_addr": "%s",
-// 				"bond": { "denom": "%s", "amount": 10 }
-// 			}
-// 		],
-// 		"unbond": []
-// 	}`, name, password, sequence, acc.GetAddress(), validatorAddr1, coinDenom))
-// 	res, body := request(t, port, "POST", "/stake/delegations", jsonStr)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	var results []ctypes.ResultBroadcastTxCommit
-// 	err := cdc.UnmarshalJSON([]byte(body), &results)
-// 	require.Nil(t, err)
-
-// 	return results[0]
-// }
-
-// func doUnbond(t *testing.T, port, seed string) (resultTx ctypes.ResultBroadcastTxCommit) {
-// 	// get the account to get the sequence
-// 	acc := getAccount(t, sendAddr)
-// 	sequence := acc.GetSequence()
-
-// 	// send
-// 	jsonStr := []byte(fmt.Sprintf(`{
-// 		"name": "%s",
-// 		"password": "%s",
-// 		"sequence": %d,
-// 		"bond": [],
-// 		"unbond": [
-// 			{
-// 				"delegator_addr": "%x",
-// 				"validator_addr": "%s",
-// 				"shares": "1"
-// 			}
-// 		]
-// 	}`, name, password, sequence, acc.GetAddress(), validatorAddr1))
-// 	res, body := request(t, port, "POST", "/stake/delegations", jsonStr)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-
-// 	var results []ctypes.ResultBroadcastTxCommit
-// 	err := cdc.UnmarshalJSON([]byte(body), &results)
-// 	require.Nil(t, err)
-
-// 	return results[0]
-// }
-
-// func getValidators(t *testing.T) []stake.Validator {
-// 	// get the account to get the sequence
-// 	res, body := request(t, port, "GET", "/stake/validators", nil)
-// 	require.Equal(t, http.StatusOK, res.StatusCode, body)
-// 	var validators stake.Validators
-// 	err := cdc.UnmarshalJSON([]byte(body), &validators)
-// 	require.Nil(t, err)
-// 	return validators
-// }
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/require"
+
+	"github.com/tendermint/tendermint/crypto/ed25519"
+	ctypes "github.com/tendermint/tendermint/rpc/core/types"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/tests"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	fapp "github.com/forbole/forboled/app"
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+	ctbclient "github.com/forbole/forboled/x/contrib/client"
+	ctbrest "github.com/forbole/forboled/x/contrib/client/rest"
+)
+
+const (
+	name     = "test"
+	password = "1234567890"
+)
+
+func TestContribs(t *testing.T) {
+	cdc := fapp.MakeCodec()
+	addr, _ := CreateAddr(t, name, password, GetKB(t))
+	cleanup, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+
+	// invite a new account
+	invitee := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	inviteKey := "0a01"
+	resultTx := doContrib(t, cdc, port, addr, invitee, "invite", fmt.Sprintf(`"key":"%s", "message":"welcome"`, inviteKey))
+	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
+	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
+
+	status := getContrib(t, cdc, port, inviteKey)
+	inviteStatus, ok := status.(*contrib.InviteStatus)
+	require.True(t, ok, "expected an invite status, got %T", status)
+	require.Equal(t, addr, inviteStatus.Contributor)
+	require.Equal(t, invitee, inviteStatus.Recipient)
+	require.Equal(t, int64(1), getContribScore(t, port, inviteKey))
+
+	require.Equal(t, int64(1), getReputeAccount(t, cdc, port, addr).Repute)
+	require.NotNil(t, getReputeAccount(t, cdc, port, invitee))
+
+	// inviting the same account again fails
+	res, body := doContribRequest(t, cdc, port, addr, invitee, "invite", `"key":"0a02"`)
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode, body)
+
+	// post to the invitee with a tip
+	postKey := "0b01"
+	resultTx = doContrib(t, cdc, port, addr, invitee, "post", fmt.Sprintf(`"key":"%s", "content":"%s", "tip":"10steak"`,
+		postKey, hex.EncodeToString([]byte("hello"))))
+	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
+
+	status = getContrib(t, cdc, port, postKey)
+	postStatus, ok := status.(*contrib.PostStatus)
+	require.True(t, ok, "expected a post status, got %T", status)
+	require.Equal(t, invitee, postStatus.Recipient)
+	require.Equal(t, "10steak", postStatus.TipTotal.String())
+	require.Equal(t, int64(1), getContribScore(t, port, postKey))
+
+	acc := getReputeAccount(t, cdc, port, addr)
+	require.Equal(t, int64(2), acc.Repute)
+	require.Equal(t, int64(90), acc.GetCoins().AmountOf("steak").Int64())
+	require.Equal(t, int64(10), getReputeAccount(t, cdc, port, invitee).GetCoins().AmountOf("steak").Int64())
+
+	// the repute and the post status before the post
+	before := resultTx.Height - 1
+	res, body = Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s?height=%d", addr, before), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var old auth.Account
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &old))
+	require.Equal(t, int64(1), old.(*types.ReputeAccount).Repute)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/contrib/%s?height=%d", postKey, before), nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode, body)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/contrib/%s?height=-1", postKey), nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// the history needs an explicit range
+	res, body = Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s/history", addr), nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+	res, body = Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s/history?from=1", addr), nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// the repute went from 1 to 2
+	res, body = Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s/history?from=1&to=%d", addr, resultTx.Height+1), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var history ctbclient.ReputeHistory
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &history))
+	require.True(t, len(history.Points) >= 2, body)
+	last := history.Points[len(history.Points)-1]
+	require.Equal(t, int64(2), last.Repute)
+	require.Equal(t, resultTx.Height, last.Height)
+
+	// the ledger explains both changes
+	res, body = Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s/ledger", addr), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var ledger ctbclient.ReputeLedger
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &ledger))
+	require.Len(t, ledger.Entries, 2, body)
+	require.Equal(t, "Invite", ledger.Entries[0].Type)
+	require.Equal(t, strings.ToUpper(inviteKey), ledger.Entries[0].Key.String())
+	require.Equal(t, invitee, ledger.Entries[0].Counterparty)
+	require.Equal(t, "Post", ledger.Entries[1].Type)
+	require.Equal(t, resultTx.Height, ledger.Entries[1].Height)
+	require.Equal(t, []contrib.ReputeTypeTotal{{"Invite", 1, 1}, {"Post", 1, 1}}, ledger.Breakdown)
+}
+
+func TestContribErrors(t *testing.T) {
+	cdc := fapp.MakeCodec()
+	addr, _ := CreateAddr(t, name, password, GetKB(t))
+	cleanup, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+
+	// unknown contrib
+	res, body := Request(t, port, "GET", "/contrib/0c01", nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode, body)
+	res, body = Request(t, port, "GET", "/contrib/0c01/score", nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode, body)
+
+	// malformed key
+	res, body = Request(t, port, "GET", "/contrib/xyz", nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// unknown account
+	unknown := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	res, body = Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s", unknown), nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode, body)
+	res, body = Request(t, port, "GET", "/reputeaccount/nobody", nil)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// unknown contrib type
+	res, body = doContribRequest(t, cdc, port, addr, unknown, "unknown", `"key":"0c02"`)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// a vote needs a vote type
+	res, body = doContribRequest(t, cdc, port, addr, addr, "vote", `"key":"0c03"`)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	// posting to an unknown account is rejected on chain
+	res, body = doContribRequest(t, cdc, port, addr, unknown, "post", `"key":"0c04", "message":"hello"`)
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode, body)
+	res, body = Request(t, port, "GET", "/contrib/0c04", nil)
+	require.Equal(t, http.StatusNoContent, res.StatusCode, body)
+}
+
+func TestContribsOffline(t *testing.T) {
+	cdc := fapp.MakeCodec()
+	kb := GetKB(t)
+	addr, _ := CreateAddr(t, name, password, kb)
+	cleanup, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+	chainID := viper.GetString(client.FlagChainID)
+	invitee := sdk.AccAddress(ed25519.GenPrivKey().PubKey().Address())
+	path := fmt.Sprintf("/contrib/%s/invite/unsigned", invitee)
+
+	// the key and password of the signing endpoint are not accepted
+	res, body := Request(t, port, "POST", path, []byte(fmt.Sprintf(`{
+		"from":"%s", "chain_id":"%s", "gas":200000, "key":"0b01",
+		"name":"%s", "password":"%s"
+	}`, addr, chainID, name, password)))
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	res, body = Request(t, port, "POST", path, []byte(fmt.Sprintf(`{
+		"from":"%s", "chain_id":"%s", "gas":200000, "key":"0b01", "message":"welcome"
+	}`, addr, chainID)))
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var unsigned ctbrest.UnsignedTx
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &unsigned))
+	acc := getReputeAccount(t, cdc, port, addr)
+	require.Equal(t, acc.GetAccountNumber(), unsigned.AccountNumber)
+	require.Equal(t, acc.GetSequence(), unsigned.Sequence)
+	require.Empty(t, unsigned.Tx.Signatures)
+
+	// the tx must be signed before broadcasting
+	res, body = broadcastSigned(t, cdc, port, unsigned.Tx)
+	require.Equal(t, http.StatusBadRequest, res.StatusCode, body)
+
+	sig, pubKey, err := kb.Sign(name, password, []byte(unsigned.SignBytes))
+	require.Nil(t, err)
+	unsigned.Tx.Signatures = []auth.StdSignature{{
+		PubKey:        pubKey,
+		Signature:     sig,
+		AccountNumber: unsigned.AccountNumber,
+		Sequence:      unsigned.Sequence,
+	}}
+	res, body = broadcastSigned(t, cdc, port, unsigned.Tx)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var resultTx ctypes.ResultBroadcastTxCommit
+	require.Nil(t, json.Unmarshal([]byte(body), &resultTx))
+	require.Equal(t, uint32(0), resultTx.CheckTx.Code)
+	require.Equal(t, uint32(0), resultTx.DeliverTx.Code)
+	tests.WaitForHeight(resultTx.Height+1, port)
+
+	status := getContrib(t, cdc, port, "0b01")
+	inviteStatus, ok := status.(*contrib.InviteStatus)
+	require.True(t, ok, "expected an invite status, got %T", status)
+	require.Equal(t, addr, inviteStatus.Contributor)
+	require.Equal(t, invitee, inviteStatus.Recipient)
+
+	// a signed tx cannot be replayed
+	res, body = broadcastSigned(t, cdc, port, unsigned.Tx)
+	require.Equal(t, http.StatusInternalServerError, res.StatusCode, body)
+}
+
+func TestContribsWebsocketOrigin(t *testing.T) {
+	viper.Set(ctbrest.FlagWebsocketOrigins, []string{"https://app.forbole.com"})
+	defer viper.Set(ctbrest.FlagWebsocketOrigins, nil)
+	addr, _ := CreateAddr(t, name, password, GetKB(t))
+	cleanup, _, port := InitializeTestLCD(t, 1, []sdk.AccAddress{addr})
+	defer cleanup()
+
+	cases := []struct {
+		origin  string
+		allowed bool
+	}{
+		{"", true},
+		{fmt.Sprintf("http://localhost:%s", port), true},
+		{"https://app.forbole.com", true},
+		{"https://evil.example.com", false},
+	}
+	for _, tc := range cases {
+		header := http.Header{}
+		if tc.origin != "" {
+			header.Set("Origin", tc.origin)
+		}
+		conn, res, err := websocket.DefaultDialer.Dial(fmt.Sprintf("ws://localhost:%s/ws/contribs", port), header)
+		if tc.allowed {
+			require.Nil(t, err, tc.origin)
+			conn.Close()
+		} else {
+			require.NotNil(t, err, tc.origin)
+			require.Equal(t, http.StatusForbidden, res.StatusCode, tc.origin)
+		}
+	}
+}
+
+// get the account of addr through the LCD
+func getReputeAccount(t *testing.T, cdc *wire.Codec, port string, addr sdk.AccAddress) *types.ReputeAccount {
+	res, body := Request(t, port, "GET", fmt.Sprintf("/reputeaccount/%s", addr), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var acc auth.Account
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &acc))
+	racc, ok := acc.(*types.ReputeAccount)
+	require.True(t, ok, "expected a repute account, got %T", acc)
+	return racc
+}
+
+func getContrib(t *testing.T, cdc *wire.Codec, port, key string) contrib.Status {
+	res, body := Request(t, port, "GET", fmt.Sprintf("/contrib/%s", key), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var status contrib.Status
+	require.Nil(t, cdc.UnmarshalJSON([]byte(body), &status))
+	return status
+}
+
+func getContribScore(t *testing.T, port, key string) int64 {
+	res, body := Request(t, port, "GET", fmt.Sprintf("/contrib/%s/score", key), nil)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	var score string
+	require.Nil(t, json.Unmarshal([]byte(body), &score))
+	var n int64
+	_, err := fmt.Sscan(score, &n)
+	require.Nil(t, err)
+	return n
+}
+
+// broadcast a tx signed offline
+func broadcastSigned(t *testing.T, cdc *wire.Codec, port string, tx auth.StdTx) (*http.Response, string) {
+	body, err := cdc.MarshalJSON(struct {
+		Tx auth.StdTx `json:"tx"`
+	}{tx})
+	require.Nil(t, err)
+	return Request(t, port, "POST", "/txs/signed", body)
+}
+
+// send a contrib of from, signed with the test key, to the recipient. The
+// fields are appended to the request body
+func doContribRequest(t *testing.T, cdc *wire.Codec, port string, from, to sdk.AccAddress, ctbtype, fields string) (*http.Response, string) {
+	acc := getReputeAccount(t, cdc, port, from)
+	chainID := viper.GetString(client.FlagChainID)
+
+	jsonStr := []byte(fmt.Sprintf(`{
+		"name":"%s",
+		"password":"%s",
+		"chain_id":"%s",
+		"account_number":"%d",
+		"sequence":"%d",
+		"gas":"200000",
+		%s
+	}`, name, password, chainID, acc.GetAccountNumber(), acc.GetSequence(), fields))
+	return Request(t, port, "POST", fmt.Sprintf("/contrib/%s/%s", to, ctbtype), jsonStr)
+}
+
+func doContrib(t *testing.T, cdc *wire.Codec, port string, from, to sdk.AccAddress, ctbtype, fields string) (resultTx ctypes.ResultBroadcastTxCommit) {
+	res, body := doContribRequest(t, cdc, port, from, to, ctbtype, fields)
+	require.Equal(t, http.StatusOK, res.StatusCode, body)
+	require.Nil(t, json.Unmarshal([]byte(body), &resultTx))
+
+	// the state is queried once the block is committed
+	tests.WaitForHeight(resultTx.Height+1, port)
+	return resultTx
+}
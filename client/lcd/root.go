@@ -71,6 +71,7 @@ func ServeCommand(cdc *wire.Codec) *cobra.Command {
 	cmd.Flags().String(client.FlagChainID, "", "The chain ID to connect to")
 	cmd.Flags().String(client.FlagNode, "tcp://localhost:26657", "Address of the node to connect to")
 	cmd.Flags().Int(flagMaxOpenConnections, 1000, "The number of maximum open connections")
+	cmd.Flags().StringSlice(ctb.FlagWebsocketOrigins, nil, "Set the origins besides the LCD that can open websockets (* for all)")
 	return cmd
 }
 
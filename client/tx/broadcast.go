@@ -0,0 +1,46 @@
+package tx
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/forbole/forboled/client/utils"
+)
+
+// BroadcastTxCmd broadcasts a tx signed with SignTxCmd
+func BroadcastTxCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "broadcast <file>",
+		Short: "Broadcast a tx signed offline",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			tx, err := utils.ReadTx(cdc, bz)
+			if err != nil {
+				return err
+			}
+			if missing := missingSignatures(tx); missing > 0 {
+				return errors.Errorf("tx is missing %d signature(s)", missing)
+			}
+
+			txBytes, err := cdc.MarshalBinary(tx)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout)
+			return cliCtx.EnsureBroadcastTx(txBytes)
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package tx
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/forbole/forboled/client/utils"
+	"github.com/forbole/forboled/types"
+)
+
+const flagOffline = "offline"
+
+// SignTxCmd signs a tx printed with --generate-only. A tx with several
+// signers is passed from signer to signer, each adding its signature in
+// the slot of its address, until it can be broadcast. Only single keys sign,
+// the crypto of this tendermint version has no multisig keys.
+func SignTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "sign <file>",
+		Short: "Sign a tx generated offline and print it",
+		Long: `Sign a tx generated offline and print it. A tx with several signers is
+passed from signer to signer, each adding its signature. Multisig keys are
+not supported, every signer signs with its own key.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			bz, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+			tx, err := utils.ReadTx(cdc, bz)
+			if err != nil {
+				return err
+			}
+
+			chainID := viper.GetString(client.FlagChainID)
+			if chainID == "" {
+				return errors.New("chain ID required but not specified")
+			}
+
+			name := viper.GetString(client.FlagFrom)
+			keybase, err := keys.GetKeyBase()
+			if err != nil {
+				return err
+			}
+			info, err := keybase.Get(name)
+			if err != nil {
+				return errors.Errorf("no key for: %s", name)
+			}
+			addr := info.GetPubKey().Address()
+
+			// the signatures follow the order of the signers
+			signers := tx.GetSigners()
+			slot := -1
+			for i, signer := range signers {
+				if bytes.Equal(signer, addr) {
+					slot = i
+				}
+			}
+			if slot < 0 {
+				return errors.Errorf("%s is not a signer of the tx", name)
+			}
+			for len(tx.Signatures) < len(signers) {
+				tx.Signatures = append(tx.Signatures, auth.StdSignature{})
+			}
+
+			accnum := viper.GetInt64(client.FlagAccountNumber)
+			sequence := viper.GetInt64(client.FlagSequence)
+			if !viper.GetBool(flagOffline) {
+				cliCtx := context.NewCLIContext().
+					WithCodec(cdc).
+					WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+				acc, err := cliCtx.GetAccount(addr)
+				if err != nil {
+					return err
+				}
+				accnum, sequence = acc.GetAccountNumber(), acc.GetSequence()
+			}
+
+			passphrase, err := keys.GetPassphrase(name)
+			if err != nil {
+				return err
+			}
+			signBytes := auth.StdSignBytes(chainID, accnum, sequence, tx.Fee, tx.Msgs, tx.Memo)
+			sig, pubkey, err := keybase.Sign(name, passphrase, signBytes)
+			if err != nil {
+				return err
+			}
+			tx.Signatures[slot] = auth.StdSignature{
+				PubKey:        pubkey,
+				Signature:     sig,
+				AccountNumber: accnum,
+				Sequence:      sequence,
+			}
+
+			if missing := missingSignatures(tx); missing > 0 {
+				fmt.Fprintf(cmd.OutOrStderr(), "%d signature(s) still missing\n", missing)
+			}
+			return utils.PrintTx(cdc, tx)
+		},
+	}
+
+	cmd.Flags().Bool(flagOffline, false, "Don't query the account number and sequence, use the flags instead")
+	return cmd
+}
+
+// missingSignatures counts the signers who have not signed tx yet
+func missingSignatures(tx auth.StdTx) (missing int) {
+	for i := range tx.GetSigners() {
+		if i >= len(tx.Signatures) || len(tx.Signatures[i].Signature) == 0 {
+			missing++
+		}
+	}
+	return missing
+}
@@ -0,0 +1,90 @@
+package utils
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdkutils "github.com/cosmos/cosmos-sdk/client/utils"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+)
+
+// FlagGenerateOnly makes a tx command print the unsigned tx instead of
+// signing and broadcasting it
+const FlagGenerateOnly = "generate-only"
+
+// GenerateOnlyCommands adds the --generate-only flag to tx commands sent
+// with SendTx
+func GenerateOnlyCommands(cmds ...*cobra.Command) []*cobra.Command {
+	for _, c := range cmds {
+		c.Flags().Bool(FlagGenerateOnly, false, "Print the unsigned tx to sign it offline, --from may then be an address")
+	}
+	return cmds
+}
+
+// GetFromAddress returns the address of the --from key. A tx generated for
+// offline signing has no key in the local keybase, --from can be the
+// address of the signer instead.
+func GetFromAddress(cliCtx context.CLIContext) (sdk.AccAddress, error) {
+	if viper.GetBool(FlagGenerateOnly) {
+		if addr, err := sdk.AccAddressFromBech32(cliCtx.FromAddressName); err == nil {
+			return addr, nil
+		}
+	}
+	return cliCtx.GetFromAddress()
+}
+
+// SendTx builds, signs and broadcasts msgs, or prints the unsigned tx with
+// --generate-only
+func SendTx(txCtx authctx.TxContext, cliCtx context.CLIContext, msgs []sdk.Msg) error {
+	if viper.GetBool(FlagGenerateOnly) {
+		return PrintUnsignedTx(txCtx, msgs)
+	}
+	return sdkutils.SendTx(txCtx, cliCtx, msgs)
+}
+
+// PrintUnsignedTx prints a StdTx holding msgs without signatures
+func PrintUnsignedTx(txCtx authctx.TxContext, msgs []sdk.Msg) error {
+	for _, msg := range msgs {
+		if err := msg.ValidateBasic(); err != nil {
+			return err
+		}
+	}
+
+	var fee []sdk.Coin
+	if txCtx.Fee != "" {
+		parsedFee, err := sdk.ParseCoin(txCtx.Fee)
+		if err != nil {
+			return err
+		}
+		fee = append(fee, parsedFee)
+	}
+
+	tx := auth.NewStdTx(msgs, auth.NewStdFee(txCtx.Gas, fee...), nil, txCtx.Memo)
+	return PrintTx(txCtx.Codec, tx)
+}
+
+// PrintTx prints tx as JSON
+func PrintTx(cdc *wire.Codec, tx auth.StdTx) error {
+	output, err := wire.MarshalJSONIndent(cdc, tx)
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// ReadTx reads a tx printed by PrintTx
+func ReadTx(cdc *wire.Codec, bz []byte) (tx auth.StdTx, err error) {
+	err = cdc.UnmarshalJSON(bz, &tx)
+	if err != nil {
+		return tx, errors.Wrap(err, "invalid tx")
+	}
+	return tx, nil
+}
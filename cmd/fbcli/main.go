@@ -16,11 +16,14 @@ import (
 	slashingcmd "github.com/cosmos/cosmos-sdk/x/slashing/client/cli"
 	stakecmd "github.com/cosmos/cosmos-sdk/x/stake/client/cli"
 	"github.com/forbole/forboled/client/lcd"
+	fbtx "github.com/forbole/forboled/client/tx"
+	"github.com/forbole/forboled/client/utils"
 	"github.com/forbole/forboled/version"
 
 	"github.com/forbole/forboled/app"
 	"github.com/forbole/forboled/types"
 	ctbcmd "github.com/forbole/forboled/x/contrib/client/cli"
+	sponsorcmd "github.com/forbole/forboled/x/sponsor/client/cli"
 )
 
 // rootCmd is the entry point for this binary
@@ -137,17 +140,81 @@ func main() {
 		govCmd,
 	)
 
+	//Add sponsor commands
+	sponsorCmd := &cobra.Command{
+		Use:   "sponsor",
+		Short: "Fee sponsorship subcommands",
+	}
+	sponsorCmd.AddCommand(
+		client.GetCommands(
+			sponsorcmd.GetGrantCmd("sponsor", cdc),
+		)...)
+	sponsorCmd.AddCommand(
+		client.PostCommands(utils.GenerateOnlyCommands(
+			sponsorcmd.GrantFeeTxCmd(cdc),
+			sponsorcmd.RevokeFeeTxCmd(cdc),
+		)...)...)
+	sponsorCmd.AddCommand(
+		sponsorcmd.GetCommunityPoolCmd(),
+	)
+	rootCmd.AddCommand(
+		sponsorCmd,
+	)
+
+	//Add bounty commands
+	bountyCmd := &cobra.Command{
+		Use:   "bounty",
+		Short: "Bounty subcommands",
+	}
+	bountyCmd.AddCommand(
+		client.GetCommands(
+			ctbcmd.GetBountyCmd("contrib", cdc),
+		)...)
+	bountyCmd.AddCommand(
+		client.PostCommands(utils.GenerateOnlyCommands(
+			ctbcmd.CreateBountyTxCmd(cdc),
+			ctbcmd.SubmitBountyTxCmd(cdc),
+			ctbcmd.AwardBountyTxCmd(cdc),
+		)...)...)
+	rootCmd.AddCommand(
+		bountyCmd,
+	)
+
 	//Add auth and bank commands
 	rootCmd.AddCommand(
 		client.GetCommands(
 			authcmd.GetAccountCmd("acc", cdc, authcmd.GetAccountDecoder(cdc)),
-			ctbcmd.GetReputeCmd("repute", cdc, types.GetReputeAccountDecoder(cdc)),
+			ctbcmd.GetReputeCmd("acc", cdc, types.GetReputeAccountDecoder(cdc)),
+			ctbcmd.GetReputeHistoryCmd("acc", cdc, types.GetReputeAccountDecoder(cdc)),
+			ctbcmd.GetReputeLedgerCmd("contrib", cdc),
 			ctbcmd.GetContribCmd("contrib", cdc),
+			ctbcmd.GetDelegationCmd("contrib", "acc", cdc, types.GetReputeAccountDecoder(cdc)),
+			ctbcmd.GetVoteTallyCmd("contrib", cdc),
+			ctbcmd.GetFeedCmd("contrib", cdc),
+			ctbcmd.ResolveNameCmd("contrib", cdc),
 		)...)
 	rootCmd.AddCommand(
 		client.PostCommands(
 			bankcmd.SendTxCmd(cdc),
-			ctbcmd.ContribTxCmd(cdc),
+		)...)
+	contribCmd := ctbcmd.ContribTxCmd(cdc)
+	contribCmd.AddCommand(
+		client.PostCommands(
+			ctbcmd.BatchContribTxCmd(cdc),
+		)...)
+	rootCmd.AddCommand(
+		client.PostCommands(utils.GenerateOnlyCommands(
+			contribCmd,
+			ctbcmd.DelegateReputeTxCmd(cdc),
+			ctbcmd.UndelegateReputeTxCmd(cdc),
+			ctbcmd.SetProfileTxCmd(cdc),
+		)...)...)
+
+	// sign and broadcast the txs generated offline
+	rootCmd.AddCommand(
+		client.PostCommands(
+			fbtx.SignTxCmd(cdc),
+			fbtx.BroadcastTxCmd(cdc),
 		)...)
 
 	// add proxy, version and key info
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const (
+	flagStore     = "store"
+	flagKey       = "key"
+	flagPredicate = "predicate"
+	flagValue     = "value"
+	flagFrom      = "from"
+	flagTo        = "to"
+)
+
+var bisectCmd = &cobra.Command{
+	Use:   "bisect <home>",
+	Short: "Find the height where a store key appeared, vanished or changed",
+	Long: `Binary search the heights of a node home for the first one where a store key
+exists, is absent or equals a value. Like git bisect, the predicate is assumed
+to hold from that height on. Without --value, equals looks for the height where
+the key took the value it has at --to.
+
+The node must be stopped and keep every height, i.e. run with --pruning nothing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBisectCmd,
+}
+
+func init() {
+	bisectCmd.Flags().String(flagStore, "", "name of the store, e.g. contrib or acc")
+	bisectCmd.Flags().String(flagKey, "", "hex encoded key")
+	bisectCmd.Flags().String(flagPredicate, "exists", "exists, absent or equals")
+	bisectCmd.Flags().String(flagValue, "", "hex encoded value for the equals predicate")
+	bisectCmd.Flags().Int64(flagFrom, 1, "first height to search")
+	bisectCmd.Flags().Int64(flagTo, 0, "last height to search, the latest one when 0")
+}
+
+func runBisectCmd(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	storeName, _ := flags.GetString(flagStore)
+	keyHex, _ := flags.GetString(flagKey)
+	predicate, _ := flags.GetString(flagPredicate)
+	valueHex, _ := flags.GetString(flagValue)
+	from, _ := flags.GetInt64(flagFrom)
+	to, _ := flags.GetInt64(flagTo)
+
+	key, err := hex.DecodeString(keyHex)
+	if err != nil || len(key) == 0 {
+		return fmt.Errorf("expected a hex encoded --%s", flagKey)
+	}
+	value, err := hex.DecodeString(valueHex)
+	if err != nil {
+		return fmt.Errorf("expected a hex encoded --%s", flagValue)
+	}
+
+	app, err := loadApp(args[0], 0)
+	if err != nil {
+		return err
+	}
+	mounted, err := storeKey(app, storeName)
+	if err != nil {
+		return err
+	}
+	if to == 0 || to > app.LastBlockHeight() {
+		to = app.LastBlockHeight()
+	}
+	if from < 1 || from > to {
+		return fmt.Errorf("invalid height range %d to %d", from, to)
+	}
+
+	get := func(height int64) ([]byte, error) {
+		err := loadHeight(app, height)
+		if err != nil {
+			return nil, err
+		}
+		ctx := app.NewContext(true, abci.Header{})
+		v := ctx.KVStore(mounted).Get(key)
+		if v == nil {
+			fmt.Fprintf(os.Stderr, "height %d: absent\n", height)
+		} else {
+			fmt.Fprintf(os.Stderr, "height %d: %X\n", height, v)
+		}
+		return v, nil
+	}
+
+	var holds func(v []byte) bool
+	var verb string
+	switch predicate {
+	case "exists":
+		holds = func(v []byte) bool { return v != nil }
+		verb = "appeared"
+	case "absent":
+		holds = func(v []byte) bool { return v == nil }
+		verb = "vanished"
+	case "equals":
+		if len(value) == 0 {
+			value, err = get(to)
+			if err != nil {
+				return err
+			}
+			if value == nil {
+				return fmt.Errorf("the key is absent at height %d, pass the expected --%s", to, flagValue)
+			}
+		}
+		holds = func(v []byte) bool { return v != nil && bytes.Equal(v, value) }
+		verb = fmt.Sprintf("took the value %X", value)
+	default:
+		return fmt.Errorf("unknown predicate %s, expected exists, absent or equals", predicate)
+	}
+
+	// the predicate does not hold at lo and holds at hi
+	lo, hi := from, to
+	v, err := get(hi)
+	if err != nil {
+		return err
+	}
+	if !holds(v) {
+		return fmt.Errorf("the predicate %s does not hold at height %d", predicate, hi)
+	}
+	v, err = get(lo)
+	if err != nil {
+		return err
+	}
+	if holds(v) {
+		fmt.Printf("the key already %s at height %d, the first one searched\n", verb, lo)
+		return nil
+	}
+	for hi-lo > 1 {
+		mid := lo + (hi-lo)/2
+		v, err = get(mid)
+		if err != nil {
+			return err
+		}
+		if holds(v) {
+			hi = mid
+		} else {
+			lo = mid
+		}
+	}
+
+	fmt.Printf("the key %s at height %d\n", verb, hi)
+	return nil
+}
@@ -0,0 +1,330 @@
+package main
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	forbole "github.com/forbole/forboled/app"
+)
+
+// defaultIterateLimit bounds the entries printed by iterate
+const defaultIterateLimit = 100
+
+var consoleCmd = &cobra.Command{
+	Use:   "console <home>",
+	Short: "Explore the stores of a node home interactively",
+	Long: `Open the application state of a node home and read commands from stdin to
+get, iterate and decode the keys of its stores, switch heights and run the
+queries the app answers over ABCI. Type help for the list of commands.
+
+Keys are hex encoded, str:<text> for a literal key such as
+str:globalAccountNumber, or account:<address> for the key of an account.
+
+The node must be stopped. Past heights are only kept by the nodes started with
+--pruning nothing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runConsoleCmd,
+}
+
+func init() {
+	consoleCmd.Flags().Int64(flagHeight, 0, "height to start at, the latest one when 0")
+}
+
+// console is the state of a console session
+type console struct {
+	app    *forbole.ForboleApp
+	cdc    *wire.Codec
+	latest int64
+	height int64
+}
+
+// consoleCommand is a command of the console
+type consoleCommand struct {
+	usage string
+	short string
+	run   func(c *console, args []string) error
+}
+
+var consoleCommands map[string]consoleCommand
+
+func init() {
+	consoleCommands = map[string]consoleCommand{
+		"help":    {"help", "list the commands", (*console).help},
+		"height":  {"height [height]", "print the current height, or switch to another one", (*console).switchHeight},
+		"stores":  {"stores", "list the stores", (*console).stores},
+		"get":     {"get <store> <key>", "print the decoded value of a key", (*console).get},
+		"iterate": {"iterate <store> [prefix] [limit]", "print the decoded entries of a store under a key prefix", (*console).iterate},
+		"decode":  {"decode <store> <key> <hex value>", "decode a value as if it was stored under a key", (*console).decode},
+		"query":   {"query <path> [hex data]", "run an ABCI query at the current height, e.g. /store/acc/key or /app/version", (*console).query},
+		"exit":    {"exit", "leave the console", nil},
+	}
+}
+
+func runConsoleCmd(cmd *cobra.Command, args []string) error {
+	height, _ := cmd.Flags().GetInt64(flagHeight)
+
+	app, err := loadApp(args[0], height)
+	if err != nil {
+		return err
+	}
+	c := &console{
+		app:    app,
+		cdc:    forbole.MakeCodec(),
+		latest: app.LastBlockHeight(),
+		height: app.LastBlockHeight(),
+	}
+	if height != 0 {
+		c.height = height
+	}
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Printf("fbdebug@%d> ", c.height)
+		if !scanner.Scan() {
+			fmt.Println()
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		command, ok := consoleCommands[fields[0]]
+		switch {
+		case !ok:
+			fmt.Printf("unknown command %s, type help for the list of commands\n", fields[0])
+		case command.run == nil:
+			return nil
+		default:
+			err := command.run(c, fields[1:])
+			if err != nil {
+				fmt.Println("error:", err)
+			}
+		}
+	}
+}
+
+func (c *console) help(args []string) error {
+	names := make([]string, 0, len(consoleCommands))
+	for name := range consoleCommands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		command := consoleCommands[name]
+		fmt.Printf("  %-35s %s\n", command.usage, command.short)
+	}
+	return nil
+}
+
+func (c *console) switchHeight(args []string) error {
+	if len(args) == 0 {
+		fmt.Printf("height %d, latest %d\n", c.height, c.latest)
+		return nil
+	}
+	height, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil || height < 1 || height > c.latest {
+		return fmt.Errorf("expected a height from 1 to %d", c.latest)
+	}
+	err = loadHeight(c.app, height)
+	if err != nil {
+		return err
+	}
+	c.height = height
+	return nil
+}
+
+func (c *console) stores(args []string) error {
+	names := make([]string, 0)
+	for name := range c.app.StoreKeys() {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	fmt.Println(strings.Join(names, " "))
+	return nil
+}
+
+// kvStore returns the store name at the current height
+func (c *console) kvStore(name string) (sdk.KVStore, error) {
+	key, err := storeKey(c.app, name)
+	if err != nil {
+		return nil, err
+	}
+	return c.app.NewContext(true, abci.Header{}).KVStore(key), nil
+}
+
+// parseKey parses a key given as hex, str:<text> or account:<address>
+func parseKey(s string) ([]byte, error) {
+	switch {
+	case strings.HasPrefix(s, "str:"):
+		return []byte(strings.TrimPrefix(s, "str:")), nil
+	case strings.HasPrefix(s, "account:"):
+		addr, err := parseAddress(strings.TrimPrefix(s, "account:"))
+		if err != nil {
+			return nil, err
+		}
+		return auth.AddressStoreKey(addr), nil
+	}
+	key, err := hex.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid key %s, expected hex, str:<text> or account:<address>", s)
+	}
+	return key, nil
+}
+
+// parseAddress parses a bech32 or hex encoded account address
+func parseAddress(s string) (sdk.AccAddress, error) {
+	addr, err := sdk.AccAddressFromBech32(s)
+	if err == nil {
+		return addr, nil
+	}
+	addr, err = sdk.AccAddressFromHex(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid address %s", s)
+	}
+	return addr, nil
+}
+
+func (c *console) get(args []string) error {
+	if len(args) != 2 {
+		return fmt.Errorf("usage: %s", consoleCommands["get"].usage)
+	}
+	store, err := c.kvStore(args[0])
+	if err != nil {
+		return err
+	}
+	key, err := parseKey(args[1])
+	if err != nil {
+		return err
+	}
+
+	value := store.Get(key)
+	if value == nil {
+		fmt.Println("absent")
+		return nil
+	}
+	fmt.Println(decodeValue(c.cdc, args[0], key, value))
+	return nil
+}
+
+func (c *console) iterate(args []string) error {
+	if len(args) < 1 || len(args) > 3 {
+		return fmt.Errorf("usage: %s", consoleCommands["iterate"].usage)
+	}
+	store, err := c.kvStore(args[0])
+	if err != nil {
+		return err
+	}
+	var prefix []byte
+	if len(args) > 1 {
+		prefix, err = parseKey(args[1])
+		if err != nil {
+			return err
+		}
+	}
+	limit := defaultIterateLimit
+	if len(args) > 2 {
+		limit, err = strconv.Atoi(args[2])
+		if err != nil || limit < 0 {
+			return fmt.Errorf("invalid limit %s", args[2])
+		}
+	}
+
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	defer iter.Close()
+	n := 0
+	for ; iter.Valid(); iter.Next() {
+		if limit > 0 && n == limit {
+			fmt.Println("...")
+			break
+		}
+		c.printPair(args[0], iter.Key(), iter.Value())
+		n++
+	}
+	fmt.Printf("%d entries\n", n)
+	return nil
+}
+
+// printPair prints a key and its decoded value, index keys have no value
+func (c *console) printPair(storeName string, key, value []byte) {
+	if len(value) == 0 {
+		fmt.Printf("%X\n", key)
+	} else {
+		fmt.Printf("%X %s\n", key, decodeValue(c.cdc, storeName, key, value))
+	}
+}
+
+func (c *console) decode(args []string) error {
+	if len(args) != 3 {
+		return fmt.Errorf("usage: %s", consoleCommands["decode"].usage)
+	}
+	key, err := parseKey(args[1])
+	if err != nil {
+		return err
+	}
+	value, err := hex.DecodeString(args[2])
+	if err != nil {
+		return fmt.Errorf("invalid value %s, expected hex", args[2])
+	}
+	fmt.Println(decodeValue(c.cdc, args[0], key, value))
+	return nil
+}
+
+func (c *console) query(args []string) error {
+	if len(args) < 1 || len(args) > 2 {
+		return fmt.Errorf("usage: %s", consoleCommands["query"].usage)
+	}
+	var data []byte
+	if len(args) > 1 {
+		var err error
+		data, err = parseKey(args[1])
+		if err != nil {
+			return err
+		}
+	}
+
+	// without a height the stores would answer at the latest one but one
+	res := c.app.Query(abci.RequestQuery{Path: args[0], Data: data, Height: c.height})
+	if !res.IsOK() {
+		return fmt.Errorf("query failed: (%d) %s", res.Code, res.Log)
+	}
+
+	// decode the values of the store queries, /store/<name>/key or subspace
+	path := strings.Split(strings.TrimPrefix(args[0], "/"), "/")
+	if len(path) == 3 && path[0] == "store" {
+		switch path[2] {
+		case "key":
+			if len(res.Value) == 0 {
+				fmt.Println("absent")
+			} else {
+				fmt.Println(decodeValue(c.cdc, path[1], data, res.Value))
+			}
+			return nil
+		case "subspace":
+			var pairs []sdk.KVPair
+			err := c.cdc.UnmarshalBinary(res.Value, &pairs)
+			if err != nil {
+				return err
+			}
+			for _, pair := range pairs {
+				c.printPair(path[1], pair.Key, pair.Value)
+			}
+			fmt.Printf("%d entries\n", len(pairs))
+			return nil
+		}
+	}
+	fmt.Printf("%s\n", res.Value)
+	return nil
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	forbole "github.com/forbole/forboled/app"
+	"github.com/forbole/forboled/x/contrib"
+)
+
+var diffCmd = &cobra.Command{
+	Use:   "diff <home>",
+	Short: "Print the keys added, removed and changed in the stores between two heights",
+	Long: `Print the keys added (+), removed (-) and changed (~) in the stores of a node
+home between two heights. The values of the account and contrib stores are
+decoded, the other ones are printed in hex.
+
+The node must be stopped and keep both heights, i.e. run with --pruning nothing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDiffCmd,
+}
+
+func init() {
+	diffCmd.Flags().Int64(flagFrom, 0, "height to diff from")
+	diffCmd.Flags().Int64(flagTo, 0, "height to diff to, the latest one when 0")
+	diffCmd.Flags().StringSlice(flagStore, []string{"contrib", "repute", "acc"}, "names of the stores to diff")
+	diffCmd.MarkFlagRequired(flagFrom)
+}
+
+// storeIterator iterates over the store name of app in key order
+func storeIterator(app *forbole.ForboleApp, name string) (sdk.Iterator, error) {
+	key, err := storeKey(app, name)
+	if err != nil {
+		return nil, err
+	}
+	ctx := app.NewContext(true, abci.Header{})
+	return ctx.KVStore(key).Iterator(nil, nil), nil
+}
+
+// decodeValue returns the JSON of a store value when its type is known from
+// its key, and its hex otherwise
+func decodeValue(cdc *wire.Codec, storeName string, key, value []byte) string {
+	var v interface{}
+	switch {
+	case (storeName == "acc" || storeName == "repute") && bytes.HasPrefix(key, []byte("account:")):
+		var acc auth.Account
+		if cdc.UnmarshalBinaryBare(value, &acc) == nil {
+			v = acc
+		}
+	case storeName == "acc" && string(key) == "globalAccountNumber":
+		var n int64
+		if cdc.UnmarshalBinary(value, &n) == nil {
+			v = n
+		}
+	case storeName == "contrib":
+		v, _ = contrib.DecodeStoreValue(cdc, key, value)
+	}
+
+	if v != nil {
+		bz, err := cdc.MarshalJSON(v)
+		if err == nil {
+			return string(bz)
+		}
+	}
+	return fmt.Sprintf("%X", value)
+}
+
+// Diff operations of a key
+const (
+	opAdded   = "+"
+	opRemoved = "-"
+	opChanged = "~"
+)
+
+// diffStores merges two iterators over the versions of a store in key order
+// and passes the keys added, removed and changed from before to after to
+// visit until it returns true
+func diffStores(before, after sdk.Iterator, visit func(op string, key, old, cur []byte) (stop bool)) {
+	for before.Valid() || after.Valid() {
+		var op string
+		var key, old, cur []byte
+		switch {
+		case !after.Valid() || before.Valid() && bytes.Compare(before.Key(), after.Key()) < 0:
+			op, key, old = opRemoved, before.Key(), before.Value()
+			before.Next()
+		case !before.Valid() || bytes.Compare(before.Key(), after.Key()) > 0:
+			op, key, cur = opAdded, after.Key(), after.Value()
+			after.Next()
+		default:
+			op, key, old, cur = opChanged, before.Key(), before.Value(), after.Value()
+			before.Next()
+			after.Next()
+			if bytes.Equal(old, cur) {
+				continue
+			}
+		}
+		if visit(op, key, old, cur) {
+			return
+		}
+	}
+}
+
+// printStoreEntry prints a key added, removed or changed in a store
+func printStoreEntry(cdc *wire.Codec, name, op string, key, old, cur []byte) {
+	switch op {
+	case opAdded:
+		printEntry(op, name, key, decodeValue(cdc, name, key, cur))
+	case opRemoved:
+		printEntry(op, name, key, decodeValue(cdc, name, key, old))
+	case opChanged:
+		printEntry(op, name, key, "")
+		fmt.Printf("    - %s\n", decodeValue(cdc, name, key, old))
+		fmt.Printf("    + %s\n", decodeValue(cdc, name, key, cur))
+	}
+}
+
+// printEntry prints a diff line, index keys have no value
+func printEntry(op, storeName string, key []byte, value string) {
+	if value == "" {
+		fmt.Printf("%s %s %X\n", op, storeName, key)
+	} else {
+		fmt.Printf("%s %s %X %s\n", op, storeName, key, value)
+	}
+}
+
+func runDiffCmd(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	from, _ := flags.GetInt64(flagFrom)
+	to, _ := flags.GetInt64(flagTo)
+	names, _ := flags.GetStringSlice(flagStore)
+
+	// both heights are read from the same database
+	db, err := openDB(args[0], "forbole")
+	if err != nil {
+		return err
+	}
+	before := forbole.NewForboleApp(log.NewNopLogger(), db, nil, baseapp.SetPruning("nothing"))
+	after := forbole.NewForboleApp(log.NewNopLogger(), db, nil, baseapp.SetPruning("nothing"))
+	if to == 0 {
+		to = after.LastBlockHeight()
+	}
+	if from < 1 || from > to {
+		return fmt.Errorf("invalid height range %d to %d", from, to)
+	}
+	if err := loadHeight(before, from); err != nil {
+		return err
+	}
+	if err := loadHeight(after, to); err != nil {
+		return err
+	}
+
+	cdc := forbole.MakeCodec()
+	counts := make(map[string]int)
+	for _, name := range names {
+		beforeIter, err := storeIterator(before, name)
+		if err != nil {
+			return err
+		}
+		afterIter, err := storeIterator(after, name)
+		if err != nil {
+			beforeIter.Close()
+			return err
+		}
+		diffStores(beforeIter, afterIter, func(op string, key, old, cur []byte) bool {
+			counts[op]++
+			printStoreEntry(cdc, name, op, key, old, cur)
+			return false
+		})
+		beforeIter.Close()
+		afterIter.Close()
+	}
+
+	fmt.Fprintf(os.Stderr, "%d added, %d removed, %d changed between heights %d and %d\n",
+		counts[opAdded], counts[opRemoved], counts[opChanged], from, to)
+	return nil
+}
@@ -0,0 +1,240 @@
+package main
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	forbole "github.com/forbole/forboled/app"
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+)
+
+const (
+	flagHeight  = "height"
+	flagFormat  = "format"
+	flagRecords = "records"
+
+	recordStatus  = "status"
+	recordAccount = "account"
+)
+
+var exportContribsCmd = &cobra.Command{
+	Use:   "export-contribs <home>",
+	Short: "Dump the contrib statuses and repute accounts of a node home to JSON Lines or CSV",
+	Long: `Dump the contrib statuses and repute accounts of a node home to stdout, one
+record per line. The node must be stopped. Past heights are only kept by the
+nodes started with --pruning nothing. The JSON Lines records are encoded with
+the app codec, the statuses decode with it.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runExportContribsCmd,
+}
+
+func init() {
+	exportContribsCmd.Flags().Int64(flagHeight, 0, "height of the state to dump, the latest one when 0")
+	exportContribsCmd.Flags().String(flagFormat, "jsonl", "output format, jsonl or csv")
+	exportContribsCmd.Flags().StringSlice(flagRecords, []string{recordStatus, recordAccount}, "records to dump, status and/or account")
+}
+
+// record is a line of the dump
+type record interface {
+	csvRow() []string
+}
+
+var csvHeader = []string{
+	"record", "key", "type", "contributor", "recipient", "score", "time",
+	"address", "name", "role", "repute", "coins", "account_number", "sequence",
+}
+
+type statusRecord struct {
+	Record      string         `json:"record"`
+	Key         cmn.HexBytes   `json:"key"`
+	Type        string         `json:"type"`
+	Contributor sdk.AccAddress `json:"contributor"`
+	Recipient   sdk.AccAddress `json:"recipient,omitempty"`
+	Score       int64          `json:"score"`
+	Time        time.Time      `json:"time"`
+	Status      contrib.Status `json:"status"`
+}
+
+func newStatusRecord(key []byte, status contrib.Status) statusRecord {
+	v := reflect.Indirect(reflect.ValueOf(status))
+	rec := statusRecord{
+		Record: recordStatus,
+		Key:    key,
+		Type:   strings.TrimSuffix(v.Type().Name(), "Status"),
+		Score:  status.GetScore(),
+		Status: status,
+	}
+	if f := v.FieldByName("Contributor"); f.IsValid() {
+		rec.Contributor = f.Interface().(sdk.AccAddress)
+	}
+	if f := v.FieldByName("Recipient"); f.IsValid() {
+		rec.Recipient = f.Interface().(sdk.AccAddress)
+	}
+	if f := v.FieldByName("Time"); f.IsValid() {
+		rec.Time = f.Interface().(time.Time)
+	}
+	return rec
+}
+
+func (rec statusRecord) csvRow() []string {
+	return []string{
+		rec.Record, rec.Key.String(), rec.Type, bech32(rec.Contributor), bech32(rec.Recipient),
+		strconv.FormatInt(rec.Score, 10), rec.Time.UTC().Format(time.RFC3339),
+		"", "", "", "", "", "", "",
+	}
+}
+
+type accountRecord struct {
+	Record        string         `json:"record"`
+	Address       sdk.AccAddress `json:"address"`
+	Name          string         `json:"name,omitempty"`
+	Role          string         `json:"role,omitempty"`
+	Repute        int64          `json:"repute"`
+	Coins         string         `json:"coins"`
+	AccountNumber int64          `json:"account_number"`
+	Sequence      int64          `json:"sequence"`
+}
+
+func newAccountRecord(acc auth.Account) accountRecord {
+	rec := accountRecord{
+		Record:        recordAccount,
+		Address:       acc.GetAddress(),
+		Coins:         acc.GetCoins().String(),
+		AccountNumber: acc.GetAccountNumber(),
+		Sequence:      acc.GetSequence(),
+	}
+	if racc, ok := acc.(*types.ReputeAccount); ok {
+		rec.Name = racc.Name
+		rec.Role = racc.Role
+		rec.Repute = racc.Repute
+	}
+	return rec
+}
+
+func (rec accountRecord) csvRow() []string {
+	return []string{
+		rec.Record, "", "", "", "", "", "",
+		bech32(rec.Address), rec.Name, rec.Role, strconv.FormatInt(rec.Repute, 10), rec.Coins,
+		strconv.FormatInt(rec.AccountNumber, 10), strconv.FormatInt(rec.Sequence, 10),
+	}
+}
+
+func bech32(addr sdk.AccAddress) string {
+	if len(addr) == 0 {
+		return ""
+	}
+	return addr.String()
+}
+
+// recordWriter writes the records in one format
+type recordWriter interface {
+	Write(rec record) error
+	Flush() error
+}
+
+type jsonlWriter struct {
+	w   *bufio.Writer
+	cdc *wire.Codec
+}
+
+func newJSONLWriter(w io.Writer, cdc *wire.Codec) *jsonlWriter {
+	return &jsonlWriter{bufio.NewWriter(w), cdc}
+}
+
+func (w *jsonlWriter) Write(rec record) error {
+	bz, err := w.cdc.MarshalJSON(rec)
+	if err != nil {
+		return err
+	}
+	_, err = w.w.Write(append(bz, '\n'))
+	return err
+}
+
+func (w *jsonlWriter) Flush() error { return w.w.Flush() }
+
+type csvWriter struct {
+	w *csv.Writer
+}
+
+func newCSVWriter(w io.Writer) (*csvWriter, error) {
+	cw := csv.NewWriter(w)
+	return &csvWriter{cw}, cw.Write(csvHeader)
+}
+
+func (w *csvWriter) Write(rec record) error { return w.w.Write(rec.csvRow()) }
+
+func (w *csvWriter) Flush() error {
+	w.w.Flush()
+	return w.w.Error()
+}
+
+func runExportContribsCmd(cmd *cobra.Command, args []string) error {
+	flags := cmd.Flags()
+	format, _ := flags.GetString(flagFormat)
+	names, _ := flags.GetStringSlice(flagRecords)
+	height, _ := flags.GetInt64(flagHeight)
+
+	var out recordWriter
+	switch format {
+	case "jsonl":
+		out = newJSONLWriter(os.Stdout, forbole.MakeCodec())
+	case "csv":
+		w, err := newCSVWriter(os.Stdout)
+		if err != nil {
+			return err
+		}
+		out = w
+	default:
+		return fmt.Errorf("unknown format %s, expected jsonl or csv", format)
+	}
+
+	records := make(map[string]bool)
+	for _, name := range names {
+		if name != recordStatus && name != recordAccount {
+			return fmt.Errorf("unknown record %s, expected %s or %s", name, recordStatus, recordAccount)
+		}
+		records[name] = true
+	}
+
+	app, err := loadApp(args[0], height)
+	if err != nil {
+		return err
+	}
+	ctx := app.NewContext(true, abci.Header{})
+
+	if records[recordStatus] {
+		app.ContribKeeper().IterateStatuses(ctx, func(key []byte, status contrib.Status) bool {
+			err = out.Write(newStatusRecord(key, status))
+			return err != nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	if records[recordAccount] {
+		app.AccountMapper().IterateAccounts(ctx, func(acc auth.Account) bool {
+			err = out.Write(newAccountRecord(acc))
+			return err != nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return out.Flush()
+}
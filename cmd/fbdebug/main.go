@@ -22,8 +22,12 @@ func init() {
 	rootCmd.AddCommand(txCmd)
 	rootCmd.AddCommand(pubkeyCmd)
 	rootCmd.AddCommand(addrCmd)
-	rootCmd.AddCommand(hackCmd)
+	rootCmd.AddCommand(bisectCmd)
+	rootCmd.AddCommand(diffCmd)
+	rootCmd.AddCommand(replayCmd)
+	rootCmd.AddCommand(consoleCmd)
 	rootCmd.AddCommand(rawBytesCmd)
+	rootCmd.AddCommand(exportContribsCmd)
 }
 
 var rootCmd = &cobra.Command{
@@ -50,12 +54,6 @@ var addrCmd = &cobra.Command{
 	RunE:  runAddrCmd,
 }
 
-var hackCmd = &cobra.Command{
-	Use:   "hack",
-	Short: "Boilerplate to Hack on an existing state by scripting some Go...",
-	RunE:  runHackCmd,
-}
-
 var rawBytesCmd = &cobra.Command{
 	Use:   "raw-bytes",
 	Short: "Convert raw bytes output (eg. [10 21 13 255]) to hex",
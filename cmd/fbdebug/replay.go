@@ -0,0 +1,333 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/blockchain"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+	sm "github.com/tendermint/tendermint/state"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+
+	forbole "github.com/forbole/forboled/app"
+)
+
+// maxDiffLines bounds the keys printed for a diverging store
+const maxDiffLines = 20
+
+// copyBatchSize bounds the keys written at once when copying the state
+const copyBatchSize = 10000
+
+var replayCmd = &cobra.Command{
+	Use:   "replay <home>",
+	Short: "Replay blocks through a fresh app and compare the app hashes",
+	Long: `Replay the blocks of a node home from --from to --to through a fresh ForboleApp
+and compare the hash of every store after each block with the one the node
+committed. The first diverging block and store are reported with the keys that
+differ.
+
+The state before --from is copied to a temporary database, removed afterwards,
+the home is not modified. The node must be stopped and keep every height, i.e. run with --pruning nothing.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runReplayCmd,
+}
+
+func init() {
+	replayCmd.Flags().Int64(flagFrom, 1, "first height to replay")
+	replayCmd.Flags().Int64(flagTo, 0, "last height to replay, the latest one when 0")
+}
+
+// commitInfo mirrors the commit info the root multistore saves for every
+// height, with the hash of each of its stores
+type commitInfo struct {
+	Version    int64
+	StoreInfos []struct {
+		Name string
+		Core struct {
+			CommitID sdk.CommitID
+		}
+	}
+}
+
+func loadCommitInfo(cdc *wire.Codec, db dbm.DB, height int64) (info commitInfo, err error) {
+	bz := db.Get([]byte(fmt.Sprintf("s/%d", height)))
+	if bz == nil {
+		return info, fmt.Errorf("no commit info at height %d", height)
+	}
+	err = cdc.UnmarshalBinary(bz, &info)
+	return info, err
+}
+
+func (info commitInfo) storeHashes() map[string][]byte {
+	hashes := make(map[string][]byte)
+	for _, store := range info.StoreInfos {
+		hashes[store.Name] = store.Core.CommitID.Hash
+	}
+	return hashes
+}
+
+// divergingStores returns the names of the stores whose hashes differ
+func divergingStores(expected, actual commitInfo) []string {
+	var names []string
+	actualHashes := actual.storeHashes()
+	for name, hash := range expected.storeHashes() {
+		if !bytes.Equal(hash, actualHashes[name]) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// copyStateBefore copies the application state of db into dst as it was
+// committed at height, leaving out the later versions so that they can be
+// committed again. The copy is written in batches, a state larger than the
+// memory would not fit in a single one.
+func copyStateBefore(cdc *wire.Codec, db, dst dbm.DB, height int64) {
+	iter := db.Iterator(nil, nil)
+	defer iter.Close()
+	batch := dst.NewBatch()
+	n := 0
+	for ; iter.Valid(); iter.Next() {
+		if isLaterVersionKey(iter.Key(), height) {
+			continue
+		}
+		batch.Set(iter.Key(), iter.Value())
+		n++
+		if n%copyBatchSize == 0 {
+			batch.Write()
+			batch = dst.NewBatch()
+		}
+	}
+	batch.Set([]byte("s/latest"), cdc.MustMarshalBinary(height))
+	batch.Write()
+}
+
+// isLaterVersionKey tells whether key is the commit info of the root
+// multistore, s/<version>, or the root of an IAVL store,
+// s/k:<store>/r/<version>, for a version after height
+func isLaterVersionKey(key []byte, height int64) bool {
+	k := string(key)
+	var version int64
+	if n, err := fmt.Sscanf(k, "s/%d", &version); err == nil && n == 1 && k == fmt.Sprintf("s/%d", version) {
+		return version > height
+	}
+	if !strings.HasPrefix(k, "s/k:") {
+		return false
+	}
+	i := strings.Index(k, "/r/")
+	if i < 0 {
+		return false
+	}
+	if n, err := fmt.Sscanf(k[i+1:], "r/%010d", &version); err == nil && n == 1 {
+		return version > height
+	}
+	return false
+}
+
+// beginBlockRequest builds the request Tendermint sends when it executes
+// block, see execBlockOnProxyApp in tendermint/state
+func beginBlockRequest(block *tmtypes.Block, lastValSet *tmtypes.ValidatorSet, stateDB dbm.DB) (abci.RequestBeginBlock, error) {
+	signVals := make([]abci.SigningValidator, len(lastValSet.Validators))
+	for i, val := range lastValSet.Validators {
+		var vote *tmtypes.Vote
+		if i < len(block.LastCommit.Precommits) {
+			vote = block.LastCommit.Precommits[i]
+		}
+		signVals[i] = abci.SigningValidator{
+			Validator:       tmtypes.TM2PB.ValidatorWithoutPubKey(val),
+			SignedLastBlock: vote != nil,
+		}
+	}
+
+	byzVals := make([]abci.Evidence, len(block.Evidence.Evidence))
+	for i, ev := range block.Evidence.Evidence {
+		valset, err := sm.LoadValidators(stateDB, ev.Height())
+		if err != nil {
+			return abci.RequestBeginBlock{}, err
+		}
+		byzVals[i] = tmtypes.TM2PB.Evidence(ev, valset, block.Time)
+	}
+
+	return abci.RequestBeginBlock{
+		Hash:   block.Hash(),
+		Header: tmtypes.TM2PB.Header(&block.Header),
+		LastCommitInfo: abci.LastCommitInfo{
+			CommitRound: int32(block.LastCommit.Round()),
+			Validators:  signVals,
+		},
+		ByzantineValidators: byzVals,
+	}, nil
+}
+
+func runReplayCmd(cmd *cobra.Command, args []string) error {
+	home := args[0]
+	flags := cmd.Flags()
+	from, _ := flags.GetInt64(flagFrom)
+	to, _ := flags.GetInt64(flagTo)
+
+	appDB, err := openDB(home, "forbole")
+	if err != nil {
+		return err
+	}
+	blockDB, err := openDB(home, "blockstore")
+	if err != nil {
+		return err
+	}
+	stateDB, err := openDB(home, "state")
+	if err != nil {
+		return err
+	}
+	blockStore := blockchain.NewBlockStore(blockDB)
+	genDoc, err := tmtypes.GenesisDocFromFile(path.Join(home, "config", "genesis.json"))
+	if err != nil {
+		return err
+	}
+
+	cdc := forbole.MakeCodec()
+	var latest int64
+	if bz := appDB.Get([]byte("s/latest")); bz != nil {
+		cdc.MustUnmarshalBinary(bz, &latest)
+	}
+	if to == 0 || to > latest {
+		to = latest
+	}
+	if to > blockStore.Height() {
+		to = blockStore.Height()
+	}
+	if from < 1 || from > to {
+		return fmt.Errorf("invalid height range %d to %d", from, to)
+	}
+
+	if from > 1 {
+		if _, err := loadCommitInfo(cdc, appDB, from-1); err != nil {
+			return fmt.Errorf("cannot replay from height %d: %v", from, err)
+		}
+	}
+
+	// the replayed state is kept on disk, it can be as large as the one of
+	// the node
+	dir, err := ioutil.TempDir("", "fbdebug-replay")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+	replayDB, err := dbm.NewGoLevelDB("forbole", dir)
+	if err != nil {
+		return err
+	}
+	defer replayDB.Close()
+
+	// the replayed app starts from genesis or from the state before from
+	if from > 1 {
+		copyStateBefore(cdc, appDB, replayDB, from-1)
+	}
+	app := forbole.NewForboleApp(log.NewNopLogger(), replayDB, nil, baseapp.SetPruning("nothing"))
+	if from == 1 {
+		genState, err := sm.MakeGenesisState(genDoc)
+		if err != nil {
+			return err
+		}
+		app.InitChain(abci.RequestInitChain{
+			Time:            genDoc.GenesisTime,
+			ChainId:         genDoc.ChainID,
+			ConsensusParams: tmtypes.TM2PB.ConsensusParams(genDoc.ConsensusParams),
+			Validators:      tmtypes.TM2PB.Validators(genState.Validators),
+			AppStateBytes:   genDoc.AppState,
+		})
+	}
+
+	for height := from; height <= to; height++ {
+		block := blockStore.LoadBlock(height)
+		if block == nil {
+			return fmt.Errorf("no block at height %d in the block store", height)
+		}
+		lastValSet := tmtypes.NewValidatorSet(nil)
+		if height > 1 {
+			lastValSet, err = sm.LoadValidators(stateDB, height-1)
+			if err != nil {
+				return err
+			}
+		}
+		req, err := beginBlockRequest(block, lastValSet, stateDB)
+		if err != nil {
+			return err
+		}
+
+		app.BeginBlock(req)
+		for _, tx := range block.Txs {
+			app.DeliverTx(tx)
+		}
+		app.EndBlock(abci.RequestEndBlock{Height: block.Height})
+		res := app.Commit()
+
+		expected, err := loadCommitInfo(cdc, appDB, height)
+		if err != nil {
+			return err
+		}
+		actual, err := loadCommitInfo(cdc, replayDB, height)
+		if err != nil {
+			return err
+		}
+		diverging := divergingStores(expected, actual)
+		if len(diverging) == 0 {
+			fmt.Printf("height %d: %d txs, app hash %X\n", height, len(block.Txs), res.Data)
+			continue
+		}
+
+		fmt.Printf("height %d: %d txs, app hash %X diverges in the stores %s\n",
+			height, len(block.Txs), res.Data, strings.Join(diverging, ", "))
+		return printDivergence(cdc, appDB, replayDB, height, diverging)
+	}
+	return nil
+}
+
+// printDivergence prints the keys of the diverging stores that differ
+// between the committed and the replayed state at height
+func printDivergence(cdc *wire.Codec, appDB, replayDB dbm.DB, height int64, stores []string) error {
+	committed := forbole.NewForboleApp(log.NewNopLogger(), appDB, nil, baseapp.SetPruning("nothing"))
+	if err := loadHeight(committed, height); err != nil {
+		return err
+	}
+	replayed := forbole.NewForboleApp(log.NewNopLogger(), replayDB, nil, baseapp.SetPruning("nothing"))
+	if err := loadHeight(replayed, height); err != nil {
+		return err
+	}
+
+	for _, name := range stores {
+		before, err := storeIterator(committed, name)
+		if err != nil {
+			return err
+		}
+		after, err := storeIterator(replayed, name)
+		if err != nil {
+			before.Close()
+			return err
+		}
+		lines := 0
+		diffStores(before, after, func(op string, key, old, cur []byte) bool {
+			if lines == maxDiffLines {
+				fmt.Printf("...\n")
+				return true
+			}
+			lines++
+			printStoreEntry(cdc, name, op, key, old, cur)
+			return false
+		})
+		before.Close()
+		after.Close()
+	}
+	return fmt.Errorf("replay diverged at height %d", height)
+}
@@ -4,7 +4,7 @@ import (
 	"encoding/json"
 	"io"
 
-	"github.com/forbole/cosmos-sdk/baseapp"
+	"github.com/cosmos/cosmos-sdk/baseapp"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -4,6 +4,7 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	cmn "github.com/tendermint/tendermint/libs/common"
 )
 
 // var _ auth.Account = (*AppAccount)(nil)
@@ -39,11 +40,17 @@ func GetAccountDecoder(cdc *wire.Codec) auth.AccountDecoder {
 
 //___________________________________________________________________________________
 
+// AdminRole is the role of the accounts that arbitrate bounties and grant
+// fees from the community pool
+const AdminRole = "Admin"
+
 type ReputeAccount struct {
 	auth.BaseAccount
-	Name   string `json:"name"`
-	Repute int64  `json:"repute"`
-	Role   string `json:"role"`
+	Name   string       `json:"name"`
+	Repute int64        `json:"repute"`
+	Role   string       `json:"role"`
+	Bio    cmn.HexBytes `json:"bio"`    // hash of the profile bio
+	Avatar cmn.HexBytes `json:"avatar"` // hash of the profile picture
 }
 
 func ProtoReputeAccount() auth.Account {
@@ -58,6 +65,11 @@ func (acc *ReputeAccount) SetRepute(repute int64) { acc.Repute = repute }
 func (acc ReputeAccount) GetRole() string         { return acc.Role }
 func (acc *ReputeAccount) SetRole(role string)    { acc.Role = role }
 
+// IsAdmin returns true if acc has the admin role, a nil account has none
+func (acc *ReputeAccount) IsAdmin() bool {
+	return acc != nil && acc.Role == AdminRole
+}
+
 // Get the AccountDecoder function for the ReputeAccount
 func GetReputeAccountDecoder(cdc *wire.Codec) auth.AccountDecoder {
 	return func(accBytes []byte) (res auth.Account, err error) {
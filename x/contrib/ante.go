@@ -0,0 +1,95 @@
+package contrib
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/forbole/forboled/types"
+)
+
+// NewAnteHandler wraps the standard ante handler with the repute based fee
+// rules. The fee payer (the first signer) must pay at least the gas price
+// times the gas wanted, discounted above DiscountRepute. Above FreeRepute,
+// contrib txs sent without a fee are free up to FreeDailyQuota per day.
+func NewAnteHandler(k Keeper, ante sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		stdTx, ok := tx.(auth.StdTx)
+		if !ok || len(stdTx.GetSigners()) == 0 {
+			return ante(ctx, tx)
+		}
+		payer := stdTx.GetSigners()[0]
+		repute := k.getRepute(ctx, payer)
+
+		// the quota is only used up if the wrapped handler accepts the tx
+		cacheCtx, writeCache := ctx.CacheContext()
+		if !k.useFreeQuota(cacheCtx, payer, repute, stdTx) {
+			denom := k.GasPriceDenom(ctx)
+			minFee := k.MinFee(ctx, repute, stdTx.Fee.Gas)
+			if stdTx.Fee.Amount.AmountOf(denom).LT(minFee) {
+				return ctx, ErrInsufficientFee(DefaultCodespace, minFee, denom).Result(), true
+			}
+		}
+
+		newCtx, res, abort = ante(cacheCtx, tx)
+		if abort {
+			return newCtx, res, abort
+		}
+		writeCache()
+		return newCtx, res, abort
+	}
+}
+
+// MinFee returns the minimum fee of a tx wanting gas, paid by an account
+// with the given repute
+func (k Keeper) MinFee(ctx sdk.Context, repute int64, gas int64) sdk.Int {
+	price := k.MinGasPrice(ctx)
+	if repute >= k.DiscountRepute(ctx) {
+		price = price.Mul(sdk.OneRat().Sub(k.DiscountRate(ctx)))
+	}
+	fee := price.Mul(sdk.NewRat(gas))
+	if !fee.GT(sdk.ZeroRat()) {
+		return sdk.ZeroInt()
+	}
+
+	// round up so the fee is never below the price
+	quo, rem := new(big.Int).QuoRem(fee.Num().BigInt(), fee.Denom().BigInt(), new(big.Int))
+	if rem.Sign() != 0 {
+		quo.Add(quo, big.NewInt(1))
+	}
+	return sdk.NewIntFromBigInt(quo)
+}
+
+// useFreeQuota counts a feeless contrib tx against the daily quota of the
+// payer and returns false if the tx does not qualify
+func (k Keeper) useFreeQuota(ctx sdk.Context, payer sdk.AccAddress, repute int64, stdTx auth.StdTx) bool {
+	if !stdTx.Fee.Amount.IsZero() || repute < k.FreeRepute(ctx) {
+		return false
+	}
+	for _, msg := range stdTx.GetMsgs() {
+		if _, ok := msg.(MsgContrib); !ok {
+			return false
+		}
+	}
+
+	day := ctx.BlockHeader().Time.Unix() / (60 * 60 * 24)
+	quota := k.getFreeQuota(ctx, payer)
+	if quota.Day != day {
+		quota = FreeQuota{Day: day}
+	}
+	if quota.Used >= k.FreeDailyQuota(ctx) {
+		return false
+	}
+	quota.Used++
+	k.setFreeQuota(ctx, payer, quota)
+	return true
+}
+
+func (k Keeper) getRepute(ctx sdk.Context, addr sdk.AccAddress) int64 {
+	acc, ok := k.am.GetAccount(ctx, addr).(*types.ReputeAccount)
+	if !ok {
+		return 0
+	}
+	return acc.GetRepute()
+}
@@ -0,0 +1,121 @@
+package contrib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func TestMinFee(t *testing.T) {
+	ctx, _, k, setter := createTestInput(t)
+	setter.SetRat(ctx, MinGasPriceKey, sdk.NewRat(1, 3))
+	setter.SetInt64(ctx, DiscountReputeKey, 100)
+	setter.SetRat(ctx, DiscountRateKey, sdk.NewRat(1, 2))
+
+	cases := []struct {
+		name   string
+		repute int64
+		gas    int64
+		fee    int64
+	}{
+		{"exact", 0, 3, 1},
+		{"rounded up", 0, 4, 2},
+		{"no gas", 0, 0, 0},
+		{"below the discount repute", 99, 4, 2},
+		{"discounted, rounded up", 100, 4, 1},
+		{"discounted, exact", 100, 6, 1},
+	}
+	for _, tc := range cases {
+		require.Equal(t, sdk.NewInt(tc.fee), k.MinFee(ctx, tc.repute, tc.gas), tc.name)
+	}
+
+	setter.SetRat(ctx, MinGasPriceKey, sdk.ZeroRat())
+	require.Equal(t, sdk.ZeroInt(), k.MinFee(ctx, 0, 1000))
+}
+
+// passAnte stands for the standard ante handler, it accepts every tx unless
+// told to abort
+func passAnte(abort bool) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Context, sdk.Result, bool) {
+		if abort {
+			return ctx, sdk.ErrUnauthorized("signature verification failed").Result(), true
+		}
+		return ctx, sdk.Result{}, false
+	}
+}
+
+func newContribTx(contributor sdk.AccAddress, fee int64) auth.StdTx {
+	msg := NewMsgContrib([]Contrib{newPost("post", contributor, addr2, genesisTime)})
+	stdFee := auth.NewStdFee(100)
+	if fee > 0 {
+		stdFee = auth.NewStdFee(100, sdk.NewInt64Coin("steak", fee))
+	}
+	return auth.NewStdTx([]sdk.Msg{msg}, stdFee, nil, "")
+}
+
+func TestAnteHandlerFee(t *testing.T) {
+	ctx, am, k, setter := createTestInput(t)
+	setter.SetRat(ctx, MinGasPriceKey, sdk.OneRat())
+	setter.SetInt64(ctx, DiscountReputeKey, 10)
+	setAccount(ctx, am, addr1, 0, nil)
+	setAccount(ctx, am, addr2, 10, nil)
+
+	cases := []struct {
+		name   string
+		payer  sdk.AccAddress
+		fee    int64
+		reject bool
+	}{
+		{"min fee", addr1, 100, false},
+		{"below the min fee", addr1, 99, true},
+		{"discounted min fee", addr2, 50, false},
+		{"below the discounted min fee", addr2, 49, true},
+	}
+	for _, tc := range cases {
+		_, res, abort := NewAnteHandler(k, passAnte(false))(ctx, newContribTx(tc.payer, tc.fee))
+		require.Equal(t, tc.reject, abort, tc.name)
+		if tc.reject {
+			require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInsufficientFee), res.Code, tc.name)
+		}
+	}
+}
+
+func TestAnteHandlerFreeQuota(t *testing.T) {
+	ctx, am, k, setter := createTestInput(t)
+	setter.SetRat(ctx, MinGasPriceKey, sdk.OneRat())
+	setter.SetInt64(ctx, FreeReputeKey, 10)
+	setter.SetInt64(ctx, FreeDailyQuotaKey, 2)
+	setAccount(ctx, am, addr1, 10, nil)
+	setAccount(ctx, am, addr2, 9, nil)
+	nextDay := genesisTime.Add(24 * time.Hour)
+
+	steps := []struct {
+		name   string
+		payer  sdk.AccAddress
+		time   time.Time
+		abort  bool
+		reject bool
+		used   int64
+	}{
+		{"below the free repute", addr2, genesisTime, false, true, 0},
+		{"aborted tx keeps the quota", addr1, genesisTime, true, false, 0},
+		{"first free tx", addr1, genesisTime, false, false, 1},
+		{"second free tx", addr1, genesisTime, false, false, 2},
+		{"quota used up", addr1, genesisTime, false, true, 2},
+		{"quota renewed the next day", addr1, nextDay, false, false, 1},
+	}
+	for _, step := range steps {
+		ctx = ctx.WithBlockHeader(abci.Header{Height: 1, Time: step.time})
+		_, res, abort := NewAnteHandler(k, passAnte(step.abort))(ctx, newContribTx(step.payer, 0))
+		require.Equal(t, step.abort || step.reject, abort, step.name)
+		if step.reject {
+			require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInsufficientFee), res.Code, step.name)
+		}
+		require.Equal(t, step.used, k.getFreeQuota(ctx, step.payer).Used, step.name)
+	}
+}
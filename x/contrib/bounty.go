@@ -0,0 +1,273 @@
+package contrib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/forbole/forboled/types"
+)
+
+// BountyEscrowAddr is the module account holding the rewards of open bounties
+var BountyEscrowAddr = sdk.AccAddress(tmhash.Sum([]byte("contrib/bounty")))
+
+// Maximum description size of a bounty in bytes
+const MaxBountyDescriptionSize = 1024
+
+// Maximum number of submissions of a bounty, they are all stored and
+// searched with the bounty
+const MaxBountySubmissions = 100
+
+// Bounty statuses
+const (
+	BountyOpen     = "Open"     // accepting submissions, the creator can award
+	BountyExpired  = "Expired"  // past expiry, the creator or an admin can award
+	BountyAwarded  = "Awarded"  // reward paid to a submission
+	BountyRefunded = "Refunded" // reward returned to the creator
+)
+
+// Bounty escrows a reward for content requested by its creator
+type Bounty struct {
+	ID          int64              `json:"id"`
+	Creator     sdk.AccAddress     `json:"creator"`
+	Description string             `json:"description"`
+	Reward      sdk.Coins          `json:"reward"`
+	Expiry      time.Time          `json:"expiry"`
+	Status      string             `json:"status"`
+	Submissions []BountySubmission `json:"submissions"`
+	Winner      sdk.AccAddress     `json:"winner"`
+}
+
+// BountySubmission is a post submitted against a bounty
+type BountySubmission struct {
+	Contributor sdk.AccAddress `json:"contributor"`
+	PostKey     []byte         `json:"post_key"`
+}
+
+func (b Bounty) String() string {
+	return fmt.Sprintf("Bounty{%d %v %v %v %s}", b.ID, b.Creator, b.Reward, b.Expiry, b.Status)
+}
+
+func (b Bounty) findSubmission(postKey []byte) (BountySubmission, bool) {
+	for _, sub := range b.Submissions {
+		if bytes.Equal(sub.PostKey, postKey) {
+			return sub, true
+		}
+	}
+	return BountySubmission{}, false
+}
+
+var (
+	bountyIDKey           = []byte{ReservedKeyPrefix, 0x04}
+	bountyKeyPrefix       = []byte{ReservedKeyPrefix, 0x05} // id -> bounty
+	bountyQueueKeyPrefix  = []byte{ReservedKeyPrefix, 0x06} // deadline, id -> nil
+	bountyQueueKeyTimeLen = 8
+)
+
+// GetBountyKey returns the store key of a bounty
+func GetBountyKey(id int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(id))
+	return append(bountyKeyPrefix, bz...)
+}
+
+func getBountyQueueKey(deadline time.Time, id int64) []byte {
+	bz := make([]byte, bountyQueueKeyTimeLen+8)
+	binary.BigEndian.PutUint64(bz, uint64(deadline.Unix()))
+	binary.BigEndian.PutUint64(bz[bountyQueueKeyTimeLen:], uint64(id))
+	return append(bountyQueueKeyPrefix, bz...)
+}
+
+// GetBounty returns a bounty by id
+func (k Keeper) GetBounty(ctx sdk.Context, id int64) (bounty Bounty, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetBountyKey(id))
+	if bz == nil {
+		return bounty, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &bounty)
+	return bounty, true
+}
+
+func (k Keeper) setBounty(ctx sdk.Context, bounty Bounty) {
+	ctx.KVStore(k.storeKey).Set(GetBountyKey(bounty.ID), k.cdc.MustMarshalBinary(bounty))
+}
+
+func (k Keeper) nextBountyID(ctx sdk.Context) int64 {
+	store := ctx.KVStore(k.storeKey)
+	var id int64
+	if bz := store.Get(bountyIDKey); bz != nil {
+		k.cdc.MustUnmarshalBinary(bz, &id)
+	}
+	store.Set(bountyIDKey, k.cdc.MustMarshalBinary(id+1))
+	return id
+}
+
+// CreateBounty escrows reward from creator until the bounty is awarded or
+// refunded
+func (k Keeper) CreateBounty(ctx sdk.Context, creator sdk.AccAddress, description string, reward sdk.Coins, expiry time.Time) (Bounty, sdk.Error) {
+	if !expiry.After(ctx.BlockHeader().Time) {
+		return Bounty{}, ErrInvalidBounty(DefaultCodespace, "expiry must be in the future")
+	}
+	_, err := k.ck.SendCoins(ctx, creator, BountyEscrowAddr, reward)
+	if err != nil {
+		return Bounty{}, err
+	}
+
+	bounty := Bounty{
+		ID:          k.nextBountyID(ctx),
+		Creator:     creator,
+		Description: description,
+		Reward:      reward,
+		Expiry:      expiry,
+		Status:      BountyOpen,
+	}
+	k.setBounty(ctx, bounty)
+	ctx.KVStore(k.storeKey).Set(getBountyQueueKey(expiry, bounty.ID), []byte{})
+	return bounty, nil
+}
+
+// SubmitBounty submits a post of contributor against an open bounty
+func (k Keeper) SubmitBounty(ctx sdk.Context, contributor sdk.AccAddress, id int64, postKey []byte) sdk.Error {
+	bounty, found := k.GetBounty(ctx, id)
+	if !found {
+		return ErrBountyNotFound(DefaultCodespace, id)
+	}
+	if bounty.Status != BountyOpen {
+		return ErrInvalidBounty(DefaultCodespace, fmt.Sprintf("bounty %d is %s", id, bounty.Status))
+	}
+	if _, found := bounty.findSubmission(postKey); found {
+		return ErrInvalidBounty(DefaultCodespace, "post already submitted")
+	}
+	if len(bounty.Submissions) >= MaxBountySubmissions {
+		return ErrInvalidBounty(DefaultCodespace, fmt.Sprintf("bounty %d has %d submissions", id, MaxBountySubmissions))
+	}
+
+	status, err := getStatus(ctx.KVStore(k.storeKey), postKey, k.cdc)
+	if err != nil {
+		return err
+	}
+	post, ok := status.(*PostStatus)
+	if !ok {
+		return ErrInvalidBounty(DefaultCodespace, fmt.Sprintf("no post at key %X", postKey))
+	}
+	if !bytes.Equal(post.Contributor, contributor) {
+		return sdk.ErrUnauthorized("only the author can submit a post")
+	}
+
+	bounty.Submissions = append(bounty.Submissions, BountySubmission{Contributor: contributor, PostKey: postKey})
+	k.setBounty(ctx, bounty)
+	return nil
+}
+
+// AwardBounty pays the reward and a repute bonus to the author of a
+// submitted post. The creator can award until the bounty is refunded,
+// admins only arbitrate expired bounties.
+func (k Keeper) AwardBounty(ctx sdk.Context, awarder sdk.AccAddress, id int64, postKey []byte) (BountySubmission, sdk.Error) {
+	bounty, found := k.GetBounty(ctx, id)
+	if !found {
+		return BountySubmission{}, ErrBountyNotFound(DefaultCodespace, id)
+	}
+	acc, _ := k.am.GetAccount(ctx, awarder).(*types.ReputeAccount)
+	switch {
+	case bounty.Status == BountyOpen && bytes.Equal(awarder, bounty.Creator):
+	case bounty.Status == BountyExpired && (bytes.Equal(awarder, bounty.Creator) || acc.IsAdmin()):
+	case bounty.Status == BountyOpen || bounty.Status == BountyExpired:
+		return BountySubmission{}, sdk.ErrUnauthorized("not allowed to award bounty")
+	default:
+		return BountySubmission{}, ErrInvalidBounty(DefaultCodespace, fmt.Sprintf("bounty %d is %s", id, bounty.Status))
+	}
+
+	sub, found := bounty.findSubmission(postKey)
+	if !found {
+		return BountySubmission{}, ErrInvalidBounty(DefaultCodespace, fmt.Sprintf("post %X was not submitted", postKey))
+	}
+
+	_, err := k.ck.SendCoins(ctx, BountyEscrowAddr, sub.Contributor, bounty.Reward)
+	if err != nil {
+		return BountySubmission{}, err
+	}
+	if acc := k.am.GetAccount(ctx, sub.Contributor); acc != nil {
+		k.updateRepute(ctx, acc, ReputeEntry{
+			Key:          postKey,
+			Type:         BountyEntryType,
+			Counterparty: bounty.Creator,
+			Delta:        k.BountyReputeBonus(ctx),
+		})
+		k.am.SetAccount(ctx, acc)
+	}
+
+	k.removeFromBountyQueue(ctx, bounty)
+	bounty.Status = BountyAwarded
+	bounty.Winner = sub.Contributor
+	k.setBounty(ctx, bounty)
+	return sub, nil
+}
+
+func (k Keeper) refundBounty(ctx sdk.Context, bounty Bounty) {
+	_, err := k.ck.SendCoins(ctx, BountyEscrowAddr, bounty.Creator, bounty.Reward)
+	if err != nil {
+		panic(err) // the escrow always holds the rewards of the open bounties
+	}
+	bounty.Status = BountyRefunded
+	k.setBounty(ctx, bounty)
+}
+
+func (k Keeper) removeFromBountyQueue(ctx sdk.Context, bounty Bounty) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(getBountyQueueKey(bounty.Expiry, bounty.ID))
+	store.Delete(getBountyQueueKey(bounty.Expiry.Add(k.BountyArbitrationPeriod(ctx)), bounty.ID))
+}
+
+// ProcessExpiredBounties moves the bounties past their deadline on. Bounties
+// without submissions are refunded at expiry, the others wait for the
+// arbitration period before being refunded.
+func (k Keeper) ProcessExpiredBounties(ctx sdk.Context) (tags sdk.Tags) {
+	store := ctx.KVStore(k.storeKey)
+	now := ctx.BlockHeader().Time
+	end := getBountyQueueKey(now.Add(time.Second), 0)
+
+	var keys [][]byte
+	iter := store.Iterator(bountyQueueKeyPrefix, end)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+		id := int64(binary.BigEndian.Uint64(key[len(bountyQueueKeyPrefix)+bountyQueueKeyTimeLen:]))
+		bounty, found := k.GetBounty(ctx, id)
+		if !found {
+			continue
+		}
+
+		switch {
+		case bounty.Status == BountyOpen && len(bounty.Submissions) > 0:
+			bounty.Status = BountyExpired
+			k.setBounty(ctx, bounty)
+			store.Set(getBountyQueueKey(bounty.Expiry.Add(k.BountyArbitrationPeriod(ctx)), id), []byte{})
+			tags = tags.AppendTag("bounty-expired", []byte(fmt.Sprint(id)))
+		case bounty.Status == BountyOpen || bounty.Status == BountyExpired:
+			k.refundBounty(ctx, bounty)
+			tags = tags.AppendTag("bounty-refunded", []byte(fmt.Sprint(id)))
+		}
+	}
+	return tags
+}
+
+// IterateBounties iterates over all the bounties
+func (k Keeper) IterateBounties(ctx sdk.Context, process func(Bounty) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), bountyKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var bounty Bounty
+		k.cdc.MustUnmarshalBinary(iter.Value(), &bounty)
+		if process(bounty) {
+			return
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package contrib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+var reward = sdk.Coins{sdk.NewInt64Coin("fbc", 50)}
+
+func getBalance(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress) int64 {
+	acc := am.GetAccount(ctx, addr)
+	if acc == nil {
+		return 0
+	}
+	return acc.GetCoins().AmountOf("fbc").Int64()
+}
+
+// submitPost posts and submits the post of contributor against bounty id
+func submitPost(t *testing.T, ctx sdk.Context, k Keeper, id int64, key string, contributor, recipient sdk.AccAddress) {
+	tags := sdk.EmptyTags()
+	_, err := k.UpdateContrib(ctx, newPost(key, contributor, recipient, ctx.BlockHeader().Time), &tags)
+	require.Nil(t, err)
+	require.Nil(t, k.SubmitBounty(ctx, contributor, id, []byte(key)))
+}
+
+func TestCreateBounty(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, sdk.Coins{sdk.NewInt64Coin("fbc", 80)})
+
+	cases := []struct {
+		name    string
+		reward  sdk.Coins
+		expiry  time.Time
+		code    sdk.CodeType
+		balance int64
+		escrow  int64
+	}{
+		{"escrowed reward", reward, genesisTime.Add(time.Hour), sdk.CodeOK, 30, 50},
+		{"past expiry", sdk.Coins{sdk.NewInt64Coin("fbc", 10)}, genesisTime, CodeInvalidBounty, 30, 50},
+		{"insufficient funds", reward, genesisTime.Add(time.Hour), sdk.CodeInsufficientCoins, 30, 50},
+	}
+	for _, tc := range cases {
+		bounty, err := k.CreateBounty(ctx, addr1, "", tc.reward, tc.expiry)
+		if tc.code != sdk.CodeOK {
+			require.NotNil(t, err, tc.name)
+			require.Equal(t, tc.code, err.Code(), tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+			stored, found := k.GetBounty(ctx, bounty.ID)
+			require.True(t, found, tc.name)
+			require.Equal(t, BountyOpen, stored.Status, tc.name)
+		}
+		require.Equal(t, tc.balance, getBalance(ctx, am, addr1), tc.name)
+		require.Equal(t, tc.escrow, getBalance(ctx, am, BountyEscrowAddr), tc.name)
+	}
+}
+
+func TestSubmitBounty(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, reward)
+	setAccount(ctx, am, addr2, 0, nil)
+	setAccount(ctx, am, addr3, 0, nil)
+
+	bounty, err := k.CreateBounty(ctx, addr1, "", reward, genesisTime.Add(time.Hour))
+	require.Nil(t, err)
+	submitPost(t, ctx, k, bounty.ID, "post", addr2, addr1)
+	tags := sdk.EmptyTags()
+	_, err = k.UpdateContrib(ctx, newVote("vote", addr2, addr1, genesisTime, 1), &tags)
+	require.Nil(t, err)
+	_, err = k.UpdateContrib(ctx, newPost("unsubmitted", addr2, addr1, genesisTime), &tags)
+	require.Nil(t, err)
+
+	cases := []struct {
+		name        string
+		contributor sdk.AccAddress
+		id          int64
+		postKey     string
+		code        sdk.CodeType
+	}{
+		{"unknown bounty", addr2, bounty.ID + 1, "post", CodeBountyNotFound},
+		{"resubmitted post", addr2, bounty.ID, "post", CodeInvalidBounty},
+		{"not a post", addr2, bounty.ID, "vote", CodeInvalidBounty},
+		{"post of another author", addr3, bounty.ID, "unsubmitted", sdk.CodeUnauthorized},
+	}
+	for _, tc := range cases {
+		err := k.SubmitBounty(ctx, tc.contributor, tc.id, []byte(tc.postKey))
+		require.NotNil(t, err, tc.name)
+		require.Equal(t, tc.code, err.Code(), tc.name)
+	}
+
+	// the submissions of a bounty are capped
+	for i := 1; i < MaxBountySubmissions; i++ {
+		submitPost(t, ctx, k, bounty.ID, fmt.Sprintf("post%d", i), addr3, addr1)
+	}
+	_, err = k.UpdateContrib(ctx, newPost("last", addr3, addr1, genesisTime), &tags)
+	require.Nil(t, err)
+	err = k.SubmitBounty(ctx, addr3, bounty.ID, []byte("last"))
+	require.NotNil(t, err)
+	require.Equal(t, CodeInvalidBounty, err.Code())
+	bounty, _ = k.GetBounty(ctx, bounty.ID)
+	require.Len(t, bounty.Submissions, MaxBountySubmissions)
+}
+
+func TestAwardBounty(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, reward)
+	setAccount(ctx, am, addr2, 0, nil)
+	setAccount(ctx, am, addr3, 0, nil)
+
+	bounty, err := k.CreateBounty(ctx, addr1, "", reward, genesisTime.Add(time.Hour))
+	require.Nil(t, err)
+	submitPost(t, ctx, k, bounty.ID, "post", addr2, addr1)
+	repute := getRepute(ctx, am, addr2)
+
+	cases := []struct {
+		name    string
+		awarder sdk.AccAddress
+		postKey string
+		code    sdk.CodeType
+	}{
+		{"not the creator", addr3, "post", sdk.CodeUnauthorized},
+		{"not submitted", addr1, "other", CodeInvalidBounty},
+		{"creator", addr1, "post", sdk.CodeOK},
+		{"already awarded", addr1, "post", CodeInvalidBounty},
+	}
+	for _, tc := range cases {
+		_, err := k.AwardBounty(ctx, tc.awarder, bounty.ID, []byte(tc.postKey))
+		if tc.code != sdk.CodeOK {
+			require.NotNil(t, err, tc.name)
+			require.Equal(t, tc.code, err.Code(), tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+		}
+	}
+
+	bounty, _ = k.GetBounty(ctx, bounty.ID)
+	require.Equal(t, BountyAwarded, bounty.Status)
+	require.Equal(t, addr2, bounty.Winner)
+	require.Equal(t, int64(50), getBalance(ctx, am, addr2))
+	require.Equal(t, int64(0), getBalance(ctx, am, BountyEscrowAddr))
+	require.Equal(t, repute+k.BountyReputeBonus(ctx), getRepute(ctx, am, addr2))
+
+	// the award took the bounty out of the expiry queue
+	ctx = ctx.WithBlockHeader(abci.Header{Time: genesisTime.Add(time.Hour + k.BountyArbitrationPeriod(ctx))})
+	require.Empty(t, k.ProcessExpiredBounties(ctx))
+	require.Equal(t, int64(0), getBalance(ctx, am, addr1))
+}
+
+func TestProcessExpiredBounties(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, sdk.Coins{sdk.NewInt64Coin("fbc", 150)})
+	setAccount(ctx, am, addr2, 0, nil)
+	setAccount(ctx, am, addr3, 0, nil)
+	admin := setAccount(ctx, am, addr4, 0, nil)
+	admin.Role = "Admin"
+	am.SetAccount(ctx, admin)
+
+	expiry := genesisTime.Add(time.Hour)
+	unanswered, err := k.CreateBounty(ctx, addr1, "", reward, expiry)
+	require.Nil(t, err)
+	arbitrated, err := k.CreateBounty(ctx, addr1, "", reward, expiry)
+	require.Nil(t, err)
+	submitPost(t, ctx, k, arbitrated.ID, "post1", addr2, addr1)
+	abandoned, err := k.CreateBounty(ctx, addr1, "", reward, expiry)
+	require.Nil(t, err)
+	submitPost(t, ctx, k, abandoned.ID, "post2", addr3, addr1)
+
+	arbitration := expiry.Add(k.BountyArbitrationPeriod(ctx))
+	steps := []struct {
+		name     string
+		time     time.Time
+		award    bool
+		statuses []string
+		balance  int64
+		escrow   int64
+	}{
+		{"before expiry", expiry.Add(-time.Second), false, []string{BountyOpen, BountyOpen, BountyOpen}, 0, 150},
+		{"expiry refunds the bounties without submissions", expiry, false, []string{BountyRefunded, BountyExpired, BountyExpired}, 50, 100},
+		{"admin arbitration", expiry.Add(time.Minute), true, []string{BountyRefunded, BountyAwarded, BountyExpired}, 50, 50},
+		{"end of the arbitration period refunds the rest", arbitration, false, []string{BountyRefunded, BountyAwarded, BountyRefunded}, 100, 0},
+	}
+	for _, step := range steps {
+		ctx = ctx.WithBlockHeader(abci.Header{Time: step.time})
+		k.ProcessExpiredBounties(ctx)
+		if step.award {
+			_, err := k.AwardBounty(ctx, addr4, arbitrated.ID, []byte("post1"))
+			require.Nil(t, err, step.name)
+		}
+
+		for i, id := range []int64{unanswered.ID, arbitrated.ID, abandoned.ID} {
+			bounty, _ := k.GetBounty(ctx, id)
+			require.Equal(t, step.statuses[i], bounty.Status, "%s: bounty %d", step.name, id)
+		}
+		require.Equal(t, step.balance, getBalance(ctx, am, addr1), step.name)
+		require.Equal(t, step.escrow, getBalance(ctx, am, BountyEscrowAddr), step.name)
+	}
+	require.Equal(t, int64(50), getBalance(ctx, am, addr2))
+	require.Equal(t, int64(0), getBalance(ctx, am, addr3))
+}
@@ -0,0 +1,230 @@
+package cli
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+)
+
+const (
+	flagPerTx  = "per-tx"
+	flagReport = "report"
+
+	// maxBatchLine bounds the size of a line of a batch file
+	maxBatchLine = 1024 * 1024
+)
+
+// batchResult reports the outcome of a line of a batch file
+type batchResult struct {
+	Line  int    `json:"line"`
+	Key   string `json:"key,omitempty"`
+	Hash  string `json:"hash,omitempty"`
+	Code  uint32 `json:"code"`
+	Log   string `json:"log,omitempty"`
+	Error string `json:"error,omitempty"`
+}
+
+type batchItem struct {
+	line int
+	ctb  contrib.Contrib
+}
+
+// BatchContribTxCmd will send the contribs of a JSONL file, packing several
+// contribs in every tx. Every line holds the fields of the contrib flags,
+// e.g. {"type":"Post","key":"01","to":"alice","content":"ab","time":"2018-09-01T00:00:00Z"}.
+// A JSONL report with the outcome of every line is written at the end.
+func BatchContribTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "batch <file.jsonl>",
+		Short: "Send the contribs of a JSONL file",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			perTx := viper.GetInt(flagPerTx)
+			if perTx <= 0 {
+				return fmt.Errorf("--%s must be positive", flagPerTx)
+			}
+
+			file, err := os.Open(args[0])
+			if err != nil {
+				return err
+			}
+			defer file.Close()
+
+			report := io.Writer(os.Stdout)
+			if path := viper.GetString(flagReport); path != "" {
+				f, err := os.Create(path)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				report = f
+			}
+
+			if err := cliCtx.EnsureAccountExists(); err != nil {
+				return err
+			}
+			from, err := cliCtx.GetFromAddress()
+			if err != nil {
+				return err
+			}
+
+			// the sequence is tracked locally from there on
+			if txCtx.AccountNumber == 0 {
+				accNum, err := cliCtx.GetAccountNumber(from)
+				if err != nil {
+					return err
+				}
+				txCtx = txCtx.WithAccountNumber(accNum)
+			}
+			if txCtx.Sequence == 0 {
+				accSeq, err := cliCtx.GetAccountSequence(from)
+				if err != nil {
+					return err
+				}
+				txCtx = txCtx.WithSequence(accSeq)
+			}
+
+			passphrase, err := keys.GetPassphrase(cliCtx.FromAddressName)
+			if err != nil {
+				return err
+			}
+
+			b := batcher{
+				cliCtx:     cliCtx,
+				txCtx:      txCtx,
+				passphrase: passphrase,
+				report:     json.NewEncoder(report),
+			}
+
+			var pending []batchItem
+			seen := make(map[string]struct{})
+			scanner := bufio.NewScanner(file)
+			scanner.Buffer(make([]byte, 64*1024), maxBatchLine)
+			for line := 1; scanner.Scan(); line++ {
+				text := strings.TrimSpace(scanner.Text())
+				if text == "" {
+					continue
+				}
+
+				var spec contribSpec
+				err := json.Unmarshal([]byte(text), &spec)
+				if err != nil {
+					b.write(batchResult{Line: line, Error: err.Error()})
+					continue
+				}
+				ctb, err := buildContrib(cliCtx, from, spec)
+				if err == nil {
+					err = ctb.ValidateBasic()
+				}
+				if err != nil {
+					b.write(batchResult{Line: line, Key: spec.Key, Error: err.Error()})
+					continue
+				}
+
+				// a tx cannot hold the same key twice
+				if _, ok := seen[string(ctb.GetKey())]; ok || len(pending) == perTx {
+					b.send(pending)
+					pending = nil
+					seen = make(map[string]struct{})
+				}
+				pending = append(pending, batchItem{line, ctb})
+				seen[string(ctb.GetKey())] = struct{}{}
+			}
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			b.send(pending)
+			return b.err
+		},
+	}
+
+	cmd.Flags().Int(flagPerTx, 20, "Number of contribs packed in a tx")
+	cmd.Flags().String(flagReport, "", "File the JSONL report is written to, stdout by default")
+	return cmd
+}
+
+// batcher signs and broadcasts the txs of a batch
+type batcher struct {
+	cliCtx     context.CLIContext
+	txCtx      authctx.TxContext
+	passphrase string
+	report     *json.Encoder
+	err        error
+}
+
+func (b *batcher) write(res batchResult) {
+	if err := b.report.Encode(res); err != nil && b.err == nil {
+		b.err = err
+	}
+}
+
+// send broadcasts the contribs of items in one tx and reports the outcome
+// of each of them. The sequence moves on unless CheckTx rejected the tx.
+func (b *batcher) send(items []batchItem) {
+	if len(items) == 0 {
+		return
+	}
+
+	ctbs := make(contrib.Contribs, len(items))
+	for i, item := range items {
+		ctbs[i] = item.ctb
+	}
+
+	var res batchResult
+	accepted := false
+	txBytes, err := b.txCtx.BuildAndSign(b.cliCtx.FromAddressName, b.passphrase, []sdk.Msg{contrib.NewMsgContrib(ctbs)})
+	if err == nil {
+		node, err := b.cliCtx.GetNode()
+		if err != nil {
+			res.Error = err.Error()
+		} else if viper.GetBool(client.FlagAsync) {
+			r, err := node.BroadcastTxAsync(txBytes)
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.Hash, res.Code, res.Log = r.Hash.String(), r.Code, r.Log
+				accepted = true
+			}
+		} else {
+			r, err := node.BroadcastTxSync(txBytes)
+			if err != nil {
+				res.Error = err.Error()
+			} else {
+				res.Hash, res.Code, res.Log = r.Hash.String(), r.Code, r.Log
+				accepted = r.Code == 0
+			}
+		}
+	} else {
+		res.Error = err.Error()
+	}
+
+	if accepted {
+		b.txCtx = b.txCtx.WithSequence(b.txCtx.Sequence + 1)
+	}
+	for _, item := range items {
+		res.Line = item.line
+		res.Key = fmt.Sprintf("%X", item.ctb.GetKey())
+		b.write(res)
+	}
+}
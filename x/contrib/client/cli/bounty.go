@@ -0,0 +1,152 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+
+	"github.com/forbole/forboled/client/utils"
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+)
+
+const (
+	flagDescription = "description"
+	flagReward      = "reward"
+	flagExpiry      = "expiry"
+)
+
+// CreateBountyTxCmd will create a bounty tx and sign it with the given key
+func CreateBountyTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "create",
+		Short: "Escrow a reward for requested content",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			reward, err := sdk.ParseCoins(viper.GetString(flagReward))
+			if err != nil {
+				return err
+			}
+
+			expiry, err := time.Parse(time.RFC3339, viper.GetString(flagExpiry))
+			if err != nil {
+				return err
+			}
+
+			msg := contrib.NewMsgCreateBounty(from, viper.GetString(flagDescription), reward, expiry)
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagDescription, "", "Description of the requested content")
+	cmd.Flags().String(flagReward, "", "Coins escrowed for the winning post")
+	cmd.Flags().String(flagExpiry, "", "Time after which the bounty stops accepting posts")
+	return cmd
+}
+
+// SubmitBountyTxCmd will create a bounty submission tx and sign it with the
+// given key
+func SubmitBountyTxCmd(cdc *wire.Codec) *cobra.Command {
+	return bountyPostTxCmd(cdc, "submit [bounty-id] [post-key]", "Submit one of your posts against a bounty",
+		func(from sdk.AccAddress, id int64, postKey []byte) sdk.Msg {
+			return contrib.NewMsgSubmitBounty(from, id, postKey)
+		})
+}
+
+// AwardBountyTxCmd will create a bounty award tx and sign it with the given
+// key
+func AwardBountyTxCmd(cdc *wire.Codec) *cobra.Command {
+	return bountyPostTxCmd(cdc, "award [bounty-id] [post-key]", "Pay a bounty to the author of a submitted post",
+		func(from sdk.AccAddress, id int64, postKey []byte) sdk.Msg {
+			return contrib.NewMsgAwardBounty(from, id, postKey)
+		})
+}
+
+func bountyPostTxCmd(cdc *wire.Codec, use, short string, buildMsg func(sdk.AccAddress, int64, []byte) sdk.Msg) *cobra.Command {
+	return &cobra.Command{
+		Use:   use,
+		Short: short,
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			postKey, err := hex.DecodeString(args[1])
+			if err != nil {
+				return err
+			}
+
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{buildMsg(from, id, postKey)})
+		},
+	}
+}
+
+// GetBountyCmd returns a query command that will display a bounty
+func GetBountyCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "query [bounty-id]",
+		Short: "Query a bounty",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			id, err := strconv.ParseInt(args[0], 10, 64)
+			if err != nil {
+				return err
+			}
+
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			res, err := cliCtx.QueryStore(contrib.GetBountyKey(id), storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("bounty %d not found", id)
+			}
+
+			var bounty contrib.Bounty
+			err = cdc.UnmarshalBinary(res, &bounty)
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, bounty)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
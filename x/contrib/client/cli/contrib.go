@@ -12,6 +12,7 @@ import (
 	"github.com/cosmos/cosmos-sdk/wire"
 
 	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/contrib/client"
 )
 
 const (
@@ -24,7 +25,9 @@ func GetContribCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "query [key]",
 		Short: "Query contrib status",
-		Args:  cobra.ExactArgs(1),
+		Long: `Query contrib status, at the latest height or at a past one with --height.
+Past heights are only kept by the nodes started with --pruning nothing.`,
+		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// find the key to look up the contrib
 			key, err := hex.DecodeString(args[0])
@@ -34,11 +37,14 @@ func GetContribCmd(storeName string, cdc *wire.Codec) *cobra.Command {
 
 			// perform query
 			cliCtx := context.NewCLIContext().WithCodec(cdc)
-			res, err := cliCtx.QueryStore(key, storeName)
+			res, err := client.QueryStore(cliCtx, key, storeName)
 			// res, err := ctx.Query(storeName)
 			if err != nil {
 				return err
 			}
+			if len(res) == 0 {
+				return fmt.Errorf("no contrib with key %X", key)
+			}
 
 			// parse out the value
 			var ctb contrib.Status
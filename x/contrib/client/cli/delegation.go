@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+
+	"github.com/forbole/forboled/client/utils"
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+// DelegateReputeTxCmd will create a repute delegation tx and sign it with
+// the given key
+func DelegateReputeTxCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "delegate-repute [delegatee address or name]",
+		Short: "Lend your voting weight to another account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			delegatee, err := client.ResolveAddress(cliCtx, client.StoreName, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := contrib.NewMsgDelegateRepute(from, delegatee)
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// UndelegateReputeTxCmd will create a repute delegation revoke tx and sign
+// it with the given key
+func UndelegateReputeTxCmd(cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "undelegate-repute",
+		Short: "Take back your voting weight",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			msg := contrib.NewMsgUndelegateRepute(from)
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetDelegationCmd returns a query command that will display the repute
+// delegation and voting weight of an account
+func GetDelegationCmd(storeName, accStoreName string, cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	return &cobra.Command{
+		Use:   "repute-delegation [address or name]",
+		Short: "Query the repute delegation and voting weight of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr, err := client.ResolveAddress(cliCtx, storeName, args[0])
+			if err != nil {
+				return err
+			}
+
+			delegation, err := client.QueryDelegation(cliCtx, storeName, accStoreName, decoder, addr)
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, delegation)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
+
+// GetVoteTallyCmd returns a query command that will display the tally of the
+// weighted votes on the contribs of an account
+func GetVoteTallyCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "vote-tally [address or name]",
+		Short: "Query the voting weight of the up and down votes on the contribs of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr, err := client.ResolveAddress(cliCtx, storeName, args[0])
+			if err != nil {
+				return err
+			}
+
+			tally, err := client.QueryVoteTally(cliCtx, storeName, addr)
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, tally)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+}
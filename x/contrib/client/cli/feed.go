@@ -0,0 +1,61 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+const (
+	flagAfter = "after"
+	flagLimit = "limit"
+)
+
+// GetFeedCmd returns a query command that will display the latest posts of
+// the accounts followed by an account
+func GetFeedCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "feed <address or name>",
+		Short: "Query the posts of the accounts followed by an account",
+		Long: `Query the posts of the accounts followed by an account, newest first.
+The posts made before the feed index was introduced are not listed.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr, err := client.ResolveAddress(cliCtx, storeName, args[0])
+			if err != nil {
+				return err
+			}
+
+			var after client.FeedCursor
+			if s := viper.GetString(flagAfter); s != "" {
+				after, err = client.ParseFeedCursor(s)
+				if err != nil {
+					return err
+				}
+			}
+
+			items, err := client.QueryFeed(cliCtx, storeName, addr, after, viper.GetInt(flagLimit))
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, items)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().String(flagAfter, "", "Only show the posts following this cursor, the cursor of the last post of a page")
+	cmd.Flags().Int(flagLimit, 20, "Maximum number of posts to show")
+	return cmd
+}
@@ -0,0 +1,57 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+const (
+	flagBreakdown = "breakdown"
+)
+
+// GetReputeLedgerCmd returns a query command that will display the repute
+// changes of an account and the contribs behind them
+func GetReputeLedgerCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repute-ledger <address or name>",
+		Short: "Query the repute changes of an account",
+		Long: `Query the repute changes of an account, each with its height, contrib key,
+contrib type, counterparty and delta. Old entries are pruned on chain with the
+ReputeLedgerMaxEntries and ReputeLedgerMaxAge params.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr, err := client.ResolveAddress(cliCtx, storeName, args[0])
+			if err != nil {
+				return err
+			}
+
+			ledger, err := client.QueryReputeLedger(cliCtx, storeName, addr)
+			if err != nil {
+				return err
+			}
+
+			var output []byte
+			if viper.GetBool(flagBreakdown) {
+				output, err = wire.MarshalJSONIndent(cdc, ledger.Breakdown)
+			} else {
+				output, err = wire.MarshalJSONIndent(cdc, ledger)
+			}
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+
+	cmd.Flags().Bool(flagBreakdown, false, "Only show the repute changes summed by contrib type")
+	return cmd
+}
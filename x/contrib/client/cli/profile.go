@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"encoding/hex"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+
+	"github.com/forbole/forboled/client/utils"
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+const (
+	flagProfileName = "profile-name"
+	flagBio         = "bio"
+	flagAvatar      = "avatar"
+)
+
+// SetProfileTxCmd will create a profile tx and sign it with the given key
+func SetProfileTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "set-profile",
+		Short: "Set the profile name, bio and avatar of your account",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			bio, err := hex.DecodeString(viper.GetString(flagBio))
+			if err != nil {
+				return err
+			}
+
+			avatar, err := hex.DecodeString(viper.GetString(flagAvatar))
+			if err != nil {
+				return err
+			}
+
+			msg := contrib.NewMsgSetProfile(from, viper.GetString(flagProfileName), bio, avatar)
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().String(flagProfileName, "", "Unique profile name, empty to release the current one")
+	cmd.Flags().String(flagBio, "", "Hash of the bio, in hex")
+	cmd.Flags().String(flagAvatar, "", "Hash of the avatar, in hex")
+	return cmd
+}
+
+// ResolveNameCmd returns a query command that will print the address
+// owning a profile name
+func ResolveNameCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resolve <name>",
+		Short: "Query the address owning a profile name",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			addr, err := client.ResolveAddress(cliCtx, storeName, args[0])
+			if err != nil {
+				return err
+			}
+			fmt.Println(addr)
+			return nil
+		},
+	}
+}
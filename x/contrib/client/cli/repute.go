@@ -3,14 +3,18 @@ package cli
 import (
 	"encoding/json"
 	"fmt"
+	"os"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/forbole/forboled/x/contrib/client"
 )
 
 // GetReputeCmd returns a query account that will display the
@@ -22,9 +26,11 @@ func GetReputeCmd(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder
 		decoder,
 	}
 	return &cobra.Command{
-		Use:   "repute <address>",
+		Use:   "repute <address or name>",
 		Short: "Query account repute",
-		RunE:  cmdr.getReputeCmd,
+		Long: `Query account repute, at the latest height or at a past one with --height.
+Past heights are only kept by the nodes started with --pruning nothing.`,
+		RunE: cmdr.getReputeCmd,
 	}
 }
 
@@ -41,7 +47,8 @@ func (c commander) getReputeCmd(cmd *cobra.Command, args []string) error {
 
 	// find the key to look up the account
 	addr := args[0]
-	key, err := sdk.AccAddressFromBech32(addr)
+	cliCtx := context.NewCLIContext().WithCodec(c.cdc)
+	key, err := client.ResolveAddress(cliCtx, client.StoreName, addr)
 	if err != nil {
 		return err
 	}
@@ -49,10 +56,11 @@ func (c commander) getReputeCmd(cmd *cobra.Command, args []string) error {
 	// ctx := context.NewCoreContextFromViper()
 	// res, err := ctx.QueryStore(auth.AddressStoreKey(key), c.storeName)
 
-	cliCtx := context.NewCLIContext().WithCodec(c.cdc)
-	res, err := cliCtx.QueryStore(auth.AddressStoreKey(key), c.storeName)
-
-	if err != nil {
+	res, err := client.QueryStore(cliCtx, auth.AddressStoreKey(key), c.storeName)
+	if _, ok := err.(client.HeightPrunedError); ok {
+		return err
+	}
+	if err != nil || len(res) == 0 {
 		return sdk.ErrUnknownAddress("No repute account with address " + addr +
 			" was found in the state.\nAre you sure there has been a transaction involving it?")
 	}
@@ -72,3 +80,70 @@ func (c commander) getReputeCmd(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+const (
+	flagFromHeight = "from-height"
+	flagToHeight   = "to-height"
+	flagStep       = "step"
+)
+
+// GetReputeHistoryCmd returns a query printing how the repute of an account
+// evolved over a range of heights
+func GetReputeHistoryCmd(storeName string, cdc *wire.Codec, decoder auth.AccountDecoder) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "repute-history <address or name>",
+		Short: "Query how an account repute evolved over a range of heights",
+		Long: `Query the account repute every --step heights from --from-height to --to-height
+and print the heights where it changed. Past heights are only kept by the
+nodes started with --pruning nothing, the pruned ones are skipped.
+
+At most 1000 heights are sampled, the step defaults to the smallest one
+sampling the range within this limit.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+
+			latest, err := client.LatestHeight(cliCtx)
+			if err != nil {
+				return err
+			}
+			from := viper.GetInt64(flagFromHeight)
+			to := viper.GetInt64(flagToHeight)
+			if to > latest {
+				to = latest
+			}
+			step := viper.GetInt64(flagStep)
+			if step == 0 {
+				step = client.ReputeHistoryStep(from, to)
+			}
+
+			// the name is looked up at the end of the range
+			cliCtx.Height = to
+			addr, err := client.ResolveAddress(cliCtx, client.StoreName, args[0])
+			if err != nil {
+				return err
+			}
+
+			history, err := client.QueryReputeHistory(cliCtx, storeName, decoder, addr, from, to, step)
+			if err != nil {
+				return err
+			}
+			if history.Pruned > 0 {
+				fmt.Fprintf(os.Stderr, "%d heights skipped, the node no longer keeps them\n", history.Pruned)
+			}
+
+			output, err := json.MarshalIndent(history, "", "  ")
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	cmd.Flags().Int64(flagFromHeight, 0, "first height of the range")
+	cmd.Flags().Int64(flagToHeight, 0, "last height of the range, the latest one when past it")
+	cmd.Flags().Int64(flagStep, 0, "heights between two queries, the smallest sampling at most 1000 heights when 0")
+	cmd.MarkFlagRequired(flagFromHeight)
+	cmd.MarkFlagRequired(flagToHeight)
+	return cmd
+}
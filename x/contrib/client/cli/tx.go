@@ -1,23 +1,24 @@
 package cli
 
 import (
-	"encoding/hex"
+	"io/ioutil"
 	"os"
-	"time"
+	"strings"
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
-	"github.com/cosmos/cosmos-sdk/client/utils"
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
 
+	"github.com/forbole/forboled/client/utils"
 	"github.com/forbole/forboled/types"
 	"github.com/forbole/forboled/x/contrib"
 	"github.com/forbole/forboled/x/contrib/client"
+	"github.com/forbole/forboled/x/sponsor"
 )
 
 const (
@@ -27,6 +28,12 @@ const (
 	flagContent = "content"
 	flagVotes   = "votes"
 	flagTime    = "time"
+	flagSponsor = "sponsor"
+	flagTip     = "tip"
+
+	flagMessage     = "message"
+	flagContentFile = "content-file"
+
 	// flagRole = "role"
 	// flagAsync  = "async"
 )
@@ -37,72 +44,34 @@ func ContribTxCmd(cdc *wire.Codec) *cobra.Command {
 		Use:   "contrib",
 		Short: "Create and sign a contrib tx",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			// ctx := context.NewCoreContextFromViper().WithAccountStore("repute").WithDecoder(types.GetReputeAccountDecoder(cdc))
-
-			// cliCtx := context.NewCLIContext().WithAccountStore("repute").WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
 			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
 			cliCtx := context.NewCLIContext().
 				WithCodec(cdc).
 				WithLogger(os.Stdout).
-				WithAccountStore("repute").
 				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
 
 			// get the from address
-			from, err := cliCtx.GetFromAddress()
+			from, err := utils.GetFromAddress(cliCtx)
 			if err != nil {
 				return err
 			}
 
-			ctbKey, err := hex.DecodeString(viper.GetString(flagKey))
-			if err != nil {
-				return err
+			spec := contribSpec{
+				Type:        viper.GetString(flagType),
+				Key:         viper.GetString(flagKey),
+				To:          viper.GetString(flagTo),
+				Content:     viper.GetString(flagContent),
+				Message:     viper.GetString(flagMessage),
+				ContentFile: viper.GetString(flagContentFile),
+				Time:        viper.GetString(flagTime),
+				Vote:        viper.GetString(flagVotes),
+				Tip:         viper.GetString(flagTip),
 			}
-
-			ctbTime, err := time.Parse(time.RFC3339, viper.GetString(flagTime))
+			ctb, err := buildContrib(cliCtx, from, spec)
 			if err != nil {
 				return err
 			}
 
-			ctbContent, err := hex.DecodeString(viper.GetString(flagContent))
-			if err != nil {
-				return err
-			}
-
-			// parse destination address
-			dest := viper.GetString(flagTo)
-			to, err := sdk.AccAddressFromBech32(dest)
-			if err != nil {
-				return err
-			}
-
-			ctbType := viper.GetString(flagType)
-			var ctb contrib.Contrib
-			switch ctbType {
-			case "Invite", "Recommend", "Post":
-
-				switch ctbType {
-				case "Invite":
-					ctb = contrib.Invite{contrib.BaseContrib2{contrib.BaseContrib{ctbKey, from, ctbTime}, to}, ctbContent}
-				case "Post":
-					ctb = contrib.Post{contrib.BaseContrib2{contrib.BaseContrib{ctbKey, from, ctbTime}, to}, ctbContent}
-				case "Recommend":
-					ctb = contrib.Recommend{contrib.BaseContrib2{contrib.BaseContrib{ctbKey, from, ctbTime}, to}, ctbContent}
-				}
-			case "Vote":
-
-				// get the vote flag to see what kind of vote
-				ctbVote := viper.GetString(flagVotes)
-				if ctbVote == "Upvote" {
-					ctb = contrib.Vote{contrib.BaseContrib3{contrib.BaseContrib{ctbKey, from, ctbTime}, to, int64(1)}, ctbContent}
-				} else if ctbVote == "Downvote" {
-					ctb = contrib.Vote{contrib.BaseContrib3{contrib.BaseContrib{ctbKey, from, ctbTime}, to, int64(-1)}, ctbContent}
-				} else {
-					return errors.New("Invalid Vote Type")
-				}
-			default:
-				return errors.New("Invalid Contrib Type")
-			}
-
 			// votes := viper.GetString(flagVotes)
 			// ctbVotes, err := strconv.ParseInt(votes, 10, 64)
 			// if err != nil {
@@ -111,6 +80,20 @@ func ContribTxCmd(cdc *wire.Codec) *cobra.Command {
 
 			// build and sign the transaction, then broadcast to Tendermint
 			msg := client.BuildContribMsg(ctb)
+			msgs := []sdk.Msg{msg}
+
+			// let the invitee transact before owning any coins
+			if allowance := viper.GetString(flagSponsor); allowance != "" {
+				invite, ok := ctb.(contrib.Invite)
+				if !ok {
+					return errors.New("Only invites can be sponsored")
+				}
+				coins, err := sdk.ParseCoins(allowance)
+				if err != nil {
+					return err
+				}
+				msgs = append(msgs, sponsor.NewMsgGrantFee(from, invite.Recipient, coins, false))
+			}
 
 			// Add async tx ??
 			// if viper.GetBool(flagAsync) {
@@ -128,16 +111,65 @@ func ContribTxCmd(cdc *wire.Codec) *cobra.Command {
 			// }
 			// // fmt.Printf("Committed at block %d. Hash: %s\n", res.Height, res.Hash.String())
 			// return nil
-			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+			return utils.SendTx(txCtx, cliCtx, msgs)
 		},
 	}
 
-	cmd.Flags().String(flagTo, "", "Address to contrib")
-	cmd.Flags().String(flagKey, "", "Key of the contrib")
-	cmd.Flags().String(flagType, "", "Type of the contrib")
-	cmd.Flags().String(flagContent, "", "Content of the contrib")
-	cmd.Flags().String(flagVotes, "", "Votes of the contrib")
-	cmd.Flags().String(flagTime, "", "Time of the contrib")
+	cmd.Flags().String(flagTo, "", "Address or profile name to contrib")
+	cmd.Flags().String(flagKey, "", "Key of the contrib in hex, derived from the contrib by default")
+	cmd.Flags().String(flagType, "", "Type of the contrib: "+strings.Join(contrib.ContribTypeNames(), ", "))
+	cmd.Flags().String(flagContent, "", "Content of the contrib in hex")
+	cmd.Flags().String(flagMessage, "", "Content of the contrib as plain text")
+	cmd.Flags().String(flagContentFile, "", "File holding the content of the contrib")
+	cmd.Flags().String(flagVotes, "", "Votes of the contrib: Upvote or Downvote")
+	cmd.Flags().String(flagTime, "", "Time of the contrib in RFC3339, now by default")
+	cmd.Flags().String(flagTip, "", "Coins sent to the recipient of a post or recommend")
+	cmd.Flags().String(flagSponsor, "", "Fee allowance granted to the invitee, e.g. 10fbtoken")
 	// cmd.Flags().Bool(flagAsync, false, "Pass the async flag to send a tx without waiting for the tx to be included in a block")
 	return cmd
 }
+
+// contribSpec describes a contrib with the values of the contrib flags
+type contribSpec struct {
+	Type        string `json:"type"`
+	Key         string `json:"key"`
+	To          string `json:"to"`
+	Content     string `json:"content"`
+	Message     string `json:"message"`
+	ContentFile string `json:"content_file"`
+	Time        string `json:"time"`
+	Vote        string `json:"vote"`
+	Tip         string `json:"tip"`
+}
+
+// buildContrib builds the contrib of from described by spec. The key is
+// derived from the contrib when missing and the time defaults to now.
+func buildContrib(cliCtx context.CLIContext, from sdk.AccAddress, spec contribSpec) (contrib.Contrib, error) {
+	message := spec.Message
+	if spec.ContentFile != "" {
+		if message != "" {
+			return nil, errors.New("give either a message or a content file, not both")
+		}
+		bz, err := ioutil.ReadFile(spec.ContentFile)
+		if err != nil {
+			return nil, err
+		}
+		message = string(bz)
+	}
+
+	// parse destination address
+	to, err := client.ResolveAddress(cliCtx, client.StoreName, spec.To)
+	if err != nil {
+		return nil, err
+	}
+
+	return client.BuildContrib(client.ContribInput{
+		Type:    spec.Type,
+		Key:     spec.Key,
+		Content: spec.Content,
+		Message: message,
+		Time:    spec.Time,
+		Vote:    spec.Vote,
+		Tip:     spec.Tip,
+	}, from, to)
+}
@@ -0,0 +1,106 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/forbole/forboled/x/contrib"
+)
+
+// FeedItem is a post of a followed account
+type FeedItem struct {
+	Key    cmn.HexBytes   `json:"key"`
+	Author sdk.AccAddress `json:"author"`
+	Time   time.Time      `json:"time"`
+	Status contrib.Status `json:"status"`
+	Cursor string         `json:"cursor"` // fetches the items following this one
+}
+
+// FeedCursor is the position of an item in a feed. Posts are ordered by time
+// then key, newest first, the key tells apart the posts of the same second.
+type FeedCursor struct {
+	Time time.Time
+	Key  []byte
+}
+
+func (c FeedCursor) String() string {
+	return fmt.Sprintf("%d-%X", c.Time.Unix(), c.Key)
+}
+
+// IsZero returns true for the cursor at the start of a feed
+func (c FeedCursor) IsZero() bool {
+	return c.Time.IsZero() && len(c.Key) == 0
+}
+
+// ParseFeedCursor parses the cursor of a feed item
+func ParseFeedCursor(s string) (FeedCursor, error) {
+	parts := strings.SplitN(s, "-", 2)
+	if len(parts) != 2 {
+		return FeedCursor{}, fmt.Errorf("invalid feed cursor %s", s)
+	}
+	sec, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return FeedCursor{}, fmt.Errorf("invalid feed cursor %s", s)
+	}
+	key, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return FeedCursor{}, fmt.Errorf("invalid feed cursor %s", s)
+	}
+	return FeedCursor{time.Unix(sec, 0).UTC(), key}, nil
+}
+
+// QueryFeed returns the latest posts of the accounts followed by addr,
+// newest first. Only the posts following the cursor are returned unless it is
+// zero, so the cursor of the last item fetches the next page. The feed only
+// holds the posts indexed when they were created, the posts made before the
+// index was introduced are missing.
+func QueryFeed(cliCtx context.CLIContext, storeName string, addr sdk.AccAddress, after FeedCursor, limit int) ([]FeedItem, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	query := contrib.FeedQuery{Addr: addr, Time: after.Time, Key: after.Key, Limit: limit}
+	opts := rpcclient.ABCIQueryOptions{Trusted: cliCtx.TrustNode}
+	result, err := node.ABCIQueryWithOptions(contrib.QueryFeedPath, cliCtx.Codec.MustMarshalBinary(query), opts)
+	if err != nil {
+		return nil, err
+	}
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, fmt.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	var posts []contrib.FeedPost
+	err = cliCtx.Codec.UnmarshalBinary(resp.Value, &posts)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]FeedItem, len(posts))
+	for i, post := range posts {
+		items[i] = FeedItem{
+			Key:    post.Key,
+			Author: post.Author,
+			Time:   post.Time,
+			Cursor: FeedCursor{post.Time, post.Key}.String(),
+		}
+		res, err := cliCtx.QueryStore(post.Key, storeName)
+		if err != nil {
+			return nil, err
+		}
+		err = cliCtx.Codec.UnmarshalBinaryBare(res, &items[i].Status)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return items, nil
+}
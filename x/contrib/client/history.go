@@ -0,0 +1,157 @@
+package client
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	rpcclient "github.com/tendermint/tendermint/rpc/client"
+
+	"github.com/forbole/forboled/types"
+)
+
+// HeightPrunedError is returned when the node keeps no state at a height,
+// either pruned or not reached yet. Nodes started with --pruning nothing
+// keep every height.
+type HeightPrunedError struct {
+	Height int64
+}
+
+func (err HeightPrunedError) Error() string {
+	return fmt.Sprintf("no state at height %d, it is pruned or not reached yet", err.Height)
+}
+
+// ParseHeight parses a height parameter, the empty string is the latest
+// height
+func ParseHeight(s string) (int64, error) {
+	if s == "" {
+		return 0, nil
+	}
+	height, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid height %s", s)
+	}
+	if height < 0 {
+		return 0, fmt.Errorf("invalid height %d", height)
+	}
+	return height, nil
+}
+
+// QueryStore queries key in storeName at cliCtx.Height, or the latest
+// height when it is 0. Unlike CLIContext.QueryStore it fails with a
+// HeightPrunedError instead of returning nothing when the height is gone.
+func QueryStore(cliCtx context.CLIContext, key cmn.HexBytes, storeName string) ([]byte, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := rpcclient.ABCIQueryOptions{
+		Height:  cliCtx.Height,
+		Trusted: cliCtx.TrustNode,
+	}
+	result, err := node.ABCIQueryWithOptions(fmt.Sprintf("/store/%s/key", storeName), key, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := result.Response
+	if !resp.IsOK() {
+		return nil, fmt.Errorf("query failed: (%d) %s", resp.Code, resp.Log)
+	}
+	// the store logs the missing versions and answers with an empty value
+	if len(resp.Value) == 0 && resp.Log != "" {
+		return nil, HeightPrunedError{cliCtx.Height}
+	}
+	return resp.Value, nil
+}
+
+// LatestHeight returns the height of the latest block of the node
+func LatestHeight(cliCtx context.CLIContext) (int64, error) {
+	node, err := cliCtx.GetNode()
+	if err != nil {
+		return 0, err
+	}
+	status, err := node.Status()
+	if err != nil {
+		return 0, err
+	}
+	return status.SyncInfo.LatestBlockHeight, nil
+}
+
+// ReputePoint is the repute of an account at a height
+type ReputePoint struct {
+	Height int64 `json:"height"`
+	Repute int64 `json:"repute"`
+}
+
+// ReputeHistory is how the repute of an account evolved over a range of
+// heights
+type ReputeHistory struct {
+	Address sdk.AccAddress `json:"address"`
+	Points  []ReputePoint  `json:"points"`
+	// Pruned counts the sampled heights the node no longer keeps
+	Pruned int64 `json:"pruned"`
+}
+
+// MaxReputeHistoryHeights bounds the heights sampled by a repute history,
+// each one is a query to the node
+const MaxReputeHistoryHeights = 1000
+
+// ReputeHistoryStep returns the smallest step sampling the heights from from
+// to to in at most MaxReputeHistoryHeights queries
+func ReputeHistoryStep(from, to int64) int64 {
+	if to < from {
+		return 1
+	}
+	return (to-from)/MaxReputeHistoryHeights + 1
+}
+
+// QueryReputeHistory samples the repute of addr every step heights from
+// from to to, both included, and keeps the points where it changed. The
+// heights before the account existed and the pruned ones are skipped.
+// Ranges sampling more than MaxReputeHistoryHeights heights are rejected.
+func QueryReputeHistory(cliCtx context.CLIContext, accStoreName string, decoder auth.AccountDecoder, addr sdk.AccAddress, from, to, step int64) (history ReputeHistory, err error) {
+	if from < 1 || to < from || step < 1 {
+		return history, fmt.Errorf("invalid height range %d to %d by %d", from, to, step)
+	}
+	if min := ReputeHistoryStep(from, to); step < min {
+		return history, fmt.Errorf("height range %d to %d by %d samples more than %d heights, use a step of at least %d",
+			from, to, step, MaxReputeHistoryHeights, min)
+	}
+
+	history.Address = addr
+	history.Points = []ReputePoint{}
+	for height := from; height <= to; height += step {
+		cliCtx.Height = height
+		res, err := QueryStore(cliCtx, auth.AddressStoreKey(addr), accStoreName)
+		if _, ok := err.(HeightPrunedError); ok {
+			history.Pruned++
+			continue
+		}
+		if err != nil {
+			return history, err
+		}
+		if len(res) == 0 {
+			continue
+		}
+
+		acc, err := decoder(res)
+		if err != nil {
+			return history, err
+		}
+		racc, ok := acc.(*types.ReputeAccount)
+		if !ok {
+			return history, fmt.Errorf("account %s is not a repute account", addr)
+		}
+
+		n := len(history.Points)
+		if n == 0 || history.Points[n-1].Repute != racc.Repute {
+			history.Points = append(history.Points, ReputePoint{height, racc.Repute})
+		}
+	}
+	return history, nil
+}
@@ -0,0 +1,154 @@
+package client
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+
+	"github.com/forbole/forboled/x/contrib"
+)
+
+// ContribInput is the user input a contrib is built from, shared by the
+// CLI flags, the batch files and the REST bodies
+type ContribInput struct {
+	Type    string
+	Key     string
+	Content string
+	Message string
+	Time    string
+	Vote    string
+	Tip     string
+}
+
+// ParseContribType returns the registered contrib type named s, in any case
+func ParseContribType(s string) (contrib.ContribTypeInfo, error) {
+	t, ok := contrib.GetContribType(s)
+	if !ok {
+		return t, fmt.Errorf("invalid contrib type %q, expected one of %s", s, strings.Join(contrib.ContribTypeNames(), ", "))
+	}
+	return t, nil
+}
+
+// BuildContrib builds the contrib of from to recipient described by input.
+// The key is derived from the contrib when missing and the time defaults to
+// now.
+func BuildContrib(input ContribInput, from, recipient sdk.AccAddress) (contrib.Contrib, error) {
+	t, err := ParseContribType(input.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := contrib.ContribFields{Contributor: from, Recipient: recipient}
+	if t.HasField(contrib.FieldContent) {
+		fields.Content, err = ParseContent(input.Content, input.Message)
+		if err != nil {
+			return nil, err
+		}
+	} else if input.Content != "" || input.Message != "" {
+		return nil, fmt.Errorf("a %s has no content", t.Name)
+	}
+
+	fields.Time, err = ParseTime(input.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.HasField(contrib.FieldVote) {
+		fields.Vote, err = ParseVote(input.Vote)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fields.Key, err = ParseKey(input.Key, t.Name, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	fields.Tip, err = sdk.ParseCoins(input.Tip)
+	if err != nil {
+		return nil, err
+	}
+	if len(fields.Tip) > 0 && !t.HasField(contrib.FieldTip) {
+		return nil, fmt.Errorf("a %s cannot carry a tip", t.Name)
+	}
+
+	return t.New(fields), nil
+}
+
+// ParseVote returns the value of an Upvote or a Downvote, in any case
+func ParseVote(s string) (int64, error) {
+	switch strings.ToLower(s) {
+	case "upvote", "up", "+1":
+		return 1, nil
+	case "downvote", "down", "-1":
+		return -1, nil
+	}
+	return 0, fmt.Errorf("invalid vote %q, expected Upvote or Downvote", s)
+}
+
+// ParseContent returns the content given either in hex or as plain text
+func ParseContent(hexContent, message string) ([]byte, error) {
+	if hexContent != "" && message != "" {
+		return nil, fmt.Errorf("give the content either in hex or as a message, not both")
+	}
+	if message != "" {
+		return []byte(message), nil
+	}
+	content, err := hex.DecodeString(hexContent)
+	if err != nil {
+		return nil, fmt.Errorf("content must be hex encoded: %v", err)
+	}
+	return content, nil
+}
+
+// ParseTime parses an RFC3339 time, an empty string is the current time
+func ParseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Now().UTC().Truncate(time.Second), nil
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return t, fmt.Errorf("time must be RFC3339, e.g. 2018-09-01T15:04:05Z: %v", err)
+	}
+	return t, nil
+}
+
+// ParseKey decodes a hex key, an empty key is derived from the type, the
+// contributor, the recipient, the time, the vote and the content of the
+// contrib, so that two different contribs made in the same second get
+// different keys
+func ParseKey(s, ctbType string, fields contrib.ContribFields) ([]byte, error) {
+	if s != "" {
+		key, err := hex.DecodeString(s)
+		if err != nil {
+			return nil, fmt.Errorf("key must be hex encoded: %v", err)
+		}
+		return key, nil
+	}
+
+	// each part is length prefixed so that no two contribs hash the same bytes
+	var bz []byte
+	for _, part := range [][]byte{
+		[]byte(ctbType),
+		fields.Contributor,
+		fields.Recipient,
+		[]byte(fields.Time.UTC().Format(time.RFC3339Nano)),
+		[]byte(strconv.FormatInt(fields.Vote, 10)),
+		fields.Content,
+	} {
+		bz = append(bz, []byte(fmt.Sprintf("%d:", len(part)))...)
+		bz = append(bz, part...)
+	}
+	key := tmhash.Sum(bz)
+	// the reserved keys cannot be used by contribs
+	for key[0] == contrib.ReservedKeyPrefix {
+		key = tmhash.Sum(key)
+	}
+	return key, nil
+}
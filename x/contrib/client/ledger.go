@@ -0,0 +1,36 @@
+package client
+
+import (
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/forbole/forboled/x/contrib"
+)
+
+// ReputeLedger is the kept repute ledger of an account and its breakdown
+// by contrib type
+type ReputeLedger struct {
+	Address   sdk.AccAddress            `json:"address"`
+	Entries   []contrib.ReputeEntry     `json:"entries"`
+	Breakdown []contrib.ReputeTypeTotal `json:"breakdown"`
+}
+
+// QueryReputeLedger returns the repute ledger of addr, oldest entry first.
+// The entries pruned on chain are missing from the breakdown as well.
+func QueryReputeLedger(cliCtx context.CLIContext, storeName string, addr sdk.AccAddress) (ledger ReputeLedger, err error) {
+	kvs, err := cliCtx.QuerySubspace(contrib.GetReputeLedgerKey(addr), storeName)
+	if err != nil {
+		return ledger, err
+	}
+
+	ledger.Address = addr
+	ledger.Entries = make([]contrib.ReputeEntry, len(kvs))
+	for i, kv := range kvs {
+		err = cliCtx.Codec.UnmarshalBinary(kv.Value, &ledger.Entries[i])
+		if err != nil {
+			return ledger, err
+		}
+	}
+	ledger.Breakdown = contrib.ReputeBreakdown(ledger.Entries)
+	return ledger, nil
+}
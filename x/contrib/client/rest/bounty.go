@@ -0,0 +1,212 @@
+package rest
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+
+	"github.com/forbole/forboled/x/contrib"
+)
+
+func registerBountyRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
+	r.HandleFunc("/bounty", createBountyHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+	r.HandleFunc("/bounty/{id}", bountyHandlerFn(cliCtx, "contrib", cdc)).Methods("GET")
+	r.HandleFunc("/bounty/{id}/submit", bountyPostHandlerFn(cdc, kb, cliCtx, func(from sdk.AccAddress, id int64, postKey []byte) sdk.Msg {
+		return contrib.NewMsgSubmitBounty(from, id, postKey)
+	})).Methods("POST")
+	r.HandleFunc("/bounty/{id}/award", bountyPostHandlerFn(cdc, kb, cliCtx, func(from sdk.AccAddress, id int64, postKey []byte) sdk.Msg {
+		return contrib.NewMsgAwardBounty(from, id, postKey)
+	})).Methods("POST")
+}
+
+type baseTxBody struct {
+	LocalAccountName string `json:"name"`
+	Password         string `json:"password"`
+	ChainID          string `json:"chain_id"`
+	Sequence         int64  `json:"sequence"`
+	AccountNumber    int64  `json:"account_number"`
+	Gas              int64  `json:"gas"`
+}
+
+type createBountyBody struct {
+	baseTxBody
+	Description string `json:"description"`
+	Reward      string `json:"reward"`
+	Expiry      string `json:"expiry"`
+}
+
+type bountyPostBody struct {
+	baseTxBody
+	PostKey string `json:"post_key"`
+}
+
+func createBountyHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m createBountyBody
+		if !readBody(w, r, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.LocalAccountName)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		reward, err := sdk.ParseCoins(m.Reward)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		expiry, err := time.Parse(time.RFC3339, m.Expiry)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		msg := contrib.NewMsgCreateBounty(sdk.AccAddress(info.GetPubKey().Address()), m.Description, reward, expiry)
+		signAndBroadcast(w, cdc, cliCtx, m.baseTxBody, msg)
+	}
+}
+
+func bountyPostHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext, buildMsg func(sdk.AccAddress, int64, []byte) sdk.Msg) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		var m bountyPostBody
+		if !readBody(w, r, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.LocalAccountName)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		postKey, err := hex.DecodeString(m.PostKey)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		msg := buildMsg(sdk.AccAddress(info.GetPubKey().Address()), id, postKey)
+		signAndBroadcast(w, cdc, cliCtx, m.baseTxBody, msg)
+	}
+}
+
+func bountyHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := cliCtx.QueryStore(contrib.GetBountyKey(id), storeName)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query bounty. Error: %s", err.Error())))
+			return
+		}
+
+		// the query will return empty if there is no such bounty
+		if len(res) == 0 {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+
+		var bounty contrib.Bounty
+		err = cdc.UnmarshalBinary(res, &bounty)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't decode bounty. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(bounty)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Could't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// readBody decodes the request body into m, writing the error if it fails
+func readBody(w http.ResponseWriter, r *http.Request, m interface{}) bool {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return false
+	}
+	err = json.Unmarshal(body, m)
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(err.Error()))
+		return false
+	}
+	return true
+}
+
+// signAndBroadcast signs msg with the local key named in base and writes
+// the result of broadcasting it
+func signAndBroadcast(w http.ResponseWriter, cdc *wire.Codec, cliCtx context.CLIContext, base baseTxBody, msg sdk.Msg) {
+	txCtx := authctx.TxContext{
+		Codec:         cdc,
+		ChainID:       base.ChainID,
+		AccountNumber: base.AccountNumber,
+		Sequence:      base.Sequence,
+		Gas:           base.Gas,
+	}
+
+	txBytes, err := txCtx.BuildAndSign(base.LocalAccountName, base.Password, []sdk.Msg{msg})
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	res, err := cliCtx.BroadcastTx(txBytes)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	output, err := json.MarshalIndent(res, "", "  ")
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(err.Error()))
+		return
+	}
+
+	w.Write(output)
+}
@@ -0,0 +1,193 @@
+package rest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+// The offline flow lets wallets sign the txs themselves: the LCD builds an
+// unsigned StdTx with the account number and sequence of the signer, and
+// broadcasts the tx once signed. No key or password reaches the server.
+func registerOfflineRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec) {
+	r.HandleFunc("/contrib/{address}/{ctbtype}/unsigned", unsignedContribHandlerFn(cdc, cliCtx)).Methods("POST")
+	r.HandleFunc("/txs/signed", broadcastSignedHandlerFn(cdc, cliCtx)).Methods("POST")
+}
+
+// unsignedContribBody has no key name, password, account number or sequence,
+// the wallet holds the key and the LCD looks up the account
+type unsignedContribBody struct {
+	From     string `json:"from"` // address or profile name of the signer
+	ChainID  string `json:"chain_id"`
+	Gas      int64  `json:"gas"`
+	Fee      string `json:"fee"`
+	Memo     string `json:"memo"`
+	Content  string `json:"content"`  // hex encoded
+	Message  string `json:"message"`  // plain text content, instead of content
+	Key      string `json:"key"`      // optional, derived from the contrib by default
+	Time     string `json:"time"`     // optional, now by default
+	VoteType string `json:"votetype"` // required by votes
+	Tip      string `json:"tip"`      // optional coins sent to the recipient of a post or recommend
+}
+
+// UnsignedTx is a tx to sign offline. SignBytes are the bytes to sign with
+// the key of the contributor, the signature then goes in Tx.Signatures with
+// the same account number and sequence.
+type UnsignedTx struct {
+	Tx            auth.StdTx `json:"tx"`
+	ChainID       string     `json:"chain_id"`
+	AccountNumber int64      `json:"account_number"`
+	Sequence      int64      `json:"sequence"`
+	SignBytes     string     `json:"sign_bytes"`
+}
+
+type signedTxBody struct {
+	Tx auth.StdTx `json:"tx"`
+}
+
+func unsignedContribHandlerFn(cdc *wire.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		to, err := client.ResolveAddress(cliCtx, "contrib", vars["address"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// unknown fields are rejected rather than ignored, e.g. a password
+		// meant for the deprecated signing endpoint
+		var m unsignedContribBody
+		dec := json.NewDecoder(r.Body)
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&m); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if m.ChainID == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("chain_id is required"))
+			return
+		}
+
+		from, err := client.ResolveAddress(cliCtx, "contrib", m.From)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		fee, err := sdk.ParseCoins(m.Fee)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		ctb, err := client.BuildContrib(client.ContribInput{
+			Type:    vars["ctbtype"],
+			Key:     m.Key,
+			Content: m.Content,
+			Message: m.Message,
+			Time:    m.Time,
+			Vote:    m.VoteType,
+			Tip:     m.Tip,
+		}, from, to)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		msgs := []sdk.Msg{client.BuildContribMsg(ctb)}
+		if err := msgs[0].ValidateBasic(); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// the account number and sequence come from the repute account of
+		// the signer
+		acc, err := cliCtx.WithAccountDecoder(types.GetReputeAccountDecoder(cdc)).GetAccount(from)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		stdFee := auth.NewStdFee(m.Gas, fee...)
+		unsigned := UnsignedTx{
+			Tx:            auth.NewStdTx(msgs, stdFee, nil, m.Memo),
+			ChainID:       m.ChainID,
+			AccountNumber: acc.GetAccountNumber(),
+			Sequence:      acc.GetSequence(),
+			SignBytes:     string(auth.StdSignBytes(m.ChainID, acc.GetAccountNumber(), acc.GetSequence(), stdFee, msgs, m.Memo)),
+		}
+
+		output, err := wire.MarshalJSONIndent(cdc, unsigned)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+func broadcastSignedHandlerFn(cdc *wire.Codec, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m signedTxBody
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		err = cdc.UnmarshalJSON(body, &m)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if len(m.Tx.Signatures) == 0 {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("tx is not signed"))
+			return
+		}
+
+		txBytes, err := cdc.MarshalBinary(m.Tx)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := cliCtx.BroadcastTx(txBytes)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		output, err := json.MarshalIndent(res, "", "  ")
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
+}
@@ -0,0 +1,82 @@
+package rest
+
+import (
+	"encoding/hex"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/crypto/keys"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+func registerProfileRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
+	r.HandleFunc("/profile", setProfileHandlerFn(cdc, kb, cliCtx)).Methods("POST")
+	r.HandleFunc("/name/{name}", nameHandlerFn(cliCtx, "contrib")).Methods("GET")
+}
+
+type setProfileBody struct {
+	baseTxBody
+	Name   string `json:"profile_name"`
+	Bio    string `json:"bio"`
+	Avatar string `json:"avatar"`
+}
+
+func setProfileHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var m setProfileBody
+		if !readBody(w, r, &m) {
+			return
+		}
+
+		info, err := kb.Get(m.LocalAccountName)
+		if err != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		bio, err := hex.DecodeString(m.Bio)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		avatar, err := hex.DecodeString(m.Avatar)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		msg := contrib.NewMsgSetProfile(sdk.AccAddress(info.GetPubKey().Address()), m.Name, bio, avatar)
+		signAndBroadcast(w, cdc, cliCtx, m.baseTxBody, msg)
+	}
+}
+
+// http request handler to resolve a profile name to its address
+func nameHandlerFn(cliCtx context.CLIContext, storeName string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+		if err := contrib.ValidateName(name); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		addr, err := client.ResolveAddress(cliCtx, storeName, name)
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write([]byte(addr.String()))
+	}
+}
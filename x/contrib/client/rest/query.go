@@ -2,20 +2,22 @@ package rest
 
 import (
 	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gorilla/mux"
 
 	"github.com/cosmos/cosmos-sdk/client/context"
-	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
 	// "github.com/cosmos/cosmos-sdk/x/stake"
 	authcmd "github.com/cosmos/cosmos-sdk/x/auth/client/cli"
 
+	"github.com/forbole/forboled/types"
 	"github.com/forbole/forboled/x/contrib"
-	// "github.com/forbole/forboled/x/contrib/client"
+	"github.com/forbole/forboled/x/contrib/client"
 )
 
 func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec) {
@@ -25,12 +27,72 @@ func registerQueryRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Cod
 	).Methods("GET")
 	r.HandleFunc(
 		"/reputeaccount/{address}",
-		reputeAccountHandlerFn(cliCtx, "repute", authcmd.GetAccountDecoder(cdc), cdc),
+		reputeAccountHandlerFn(cliCtx, "acc", authcmd.GetAccountDecoder(cdc), cdc),
 	).Methods("GET")
 	r.HandleFunc(
 		"/contrib/{key}/score",
 		contribScoreHandlerFn(cliCtx, "contrib", cdc),
 	).Methods("GET")
+	r.HandleFunc(
+		"/feed/{address}",
+		feedHandlerFn(cliCtx, "contrib", cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/reputeaccount/{address}/delegation",
+		delegationHandlerFn(cliCtx, "contrib", "acc", types.GetReputeAccountDecoder(cdc), cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/reputeaccount/{address}/votes",
+		voteTallyHandlerFn(cliCtx, "contrib", cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/reputeaccount/{address}/history",
+		reputeHistoryHandlerFn(cliCtx, "acc", types.GetReputeAccountDecoder(cdc), cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/reputeaccount/{address}/ledger",
+		reputeLedgerHandlerFn(cliCtx, "contrib", cdc),
+	).Methods("GET")
+	r.HandleFunc(
+		"/contrib-types",
+		contribTypesHandlerFn(),
+	).Methods("GET")
+}
+
+// withHeight returns cliCtx querying at the height query parameter, the
+// latest height when it is missing
+func withHeight(cliCtx context.CLIContext, r *http.Request) (context.CLIContext, error) {
+	height, err := client.ParseHeight(r.URL.Query().Get("height"))
+	if err != nil {
+		return cliCtx, err
+	}
+	cliCtx.Height = height
+	return cliCtx, nil
+}
+
+// writeQueryError answers a failed query, a height the node no longer
+// keeps is not found
+func writeQueryError(w http.ResponseWriter, msg string, err error) {
+	if _, ok := err.(client.HeightPrunedError); ok {
+		w.WriteHeader(http.StatusNotFound)
+	} else {
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	w.Write([]byte(fmt.Sprintf("%s. Error: %s", msg, err.Error())))
+}
+
+// http request handler listing the contrib types and the fields they read
+func contribTypesHandlerFn() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		output, err := json.Marshal(contrib.ContribTypes())
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		w.Write(output)
+	}
 }
 
 // http request handler to query delegator bonding status
@@ -59,10 +121,16 @@ func contribHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wire.Cod
 			return
 		}
 
-		res, err := cliCtx.QueryStore(key, storeName)
+		cliCtx, err := withHeight(cliCtx, r)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Sprintf("Couldn't query contribution. Error: %s", err.Error())))
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := client.QueryStore(cliCtx, key, storeName)
+		if err != nil {
+			writeQueryError(w, "Couldn't query contribution", err)
 			return
 		}
 
@@ -96,17 +164,23 @@ func reputeAccountHandlerFn(cliCtx context.CLIContext, storeName string, decoder
 		vars := mux.Vars(r)
 		addr := vars["address"]
 
-		key, err := sdk.AccAddressFromBech32(addr)
+		cliCtx, err := withHeight(cliCtx, r)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
 			return
 		}
 
-		res, err := cliCtx.QueryStore(auth.AddressStoreKey(key), storeName)
+		key, err := client.ResolveAddress(cliCtx, "contrib", addr)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Sprintf("Could't query account. Error: %s", err.Error())))
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := client.QueryStore(cliCtx, auth.AddressStoreKey(key), storeName)
+		if err != nil {
+			writeQueryError(w, "Could't query account", err)
 			return
 		}
 
@@ -136,6 +210,110 @@ func reputeAccountHandlerFn(cliCtx context.CLIContext, storeName string, decoder
 	}
 }
 
+// http request handler to query how the repute of an account evolved, from
+// the from height to the to height every step heights. The step defaults to
+// the smallest one sampling at most client.MaxReputeHistoryHeights heights.
+func reputeHistoryHandlerFn(cliCtx context.CLIContext, storeName string, decoder auth.AccountDecoder, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		latest, err := client.LatestHeight(cliCtx)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query latest height. Error: %s", err.Error())))
+			return
+		}
+
+		// each sampled height is a query to the node, the range is explicit
+		if r.URL.Query().Get("from") == "" || r.URL.Query().Get("to") == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("from and to are required"))
+			return
+		}
+		from, err := client.ParseHeight(r.URL.Query().Get("from"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		to, err := client.ParseHeight(r.URL.Query().Get("to"))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+		if to > latest {
+			to = latest
+		}
+		step := client.ReputeHistoryStep(from, to)
+		if s := r.URL.Query().Get("step"); s != "" {
+			step, err = strconv.ParseInt(s, 10, 64)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		// the name is looked up at the end of the range
+		ctx := cliCtx
+		ctx.Height = to
+		addr, err := client.ResolveAddress(ctx, "contrib", vars["address"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		history, err := client.QueryReputeHistory(cliCtx, storeName, decoder, addr, from, to, step)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(fmt.Sprintf("Couldn't query repute history. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(history)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Could't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query the repute ledger of an account and its
+// breakdown by contrib type
+func reputeLedgerHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		addr, err := client.ResolveAddress(cliCtx, storeName, vars["address"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		ledger, err := client.QueryReputeLedger(cliCtx, storeName, addr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query repute ledger. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(ledger)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Could't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
 func contribScoreHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 
@@ -161,10 +339,16 @@ func contribScoreHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wir
 			return
 		}
 
-		res, err := cliCtx.QueryStore(key, storeName)
+		cliCtx, err := withHeight(cliCtx, r)
 		if err != nil {
-			w.WriteHeader(http.StatusInternalServerError)
-			w.Write([]byte(fmt.Sprintf("Couldn't query contribution. Error: %s", err.Error())))
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		res, err := client.QueryStore(cliCtx, key, storeName)
+		if err != nil {
+			writeQueryError(w, "Couldn't query contribution", err)
 			return
 		}
 
@@ -192,3 +376,112 @@ func contribScoreHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wir
 		w.Write(output)
 	}
 }
+
+func voteTallyHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		addr, err := client.ResolveAddress(cliCtx, storeName, vars["address"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		tally, err := client.QueryVoteTally(cliCtx.WithCodec(cdc), storeName, addr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query vote tally. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(tally)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Could't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+func delegationHandlerFn(cliCtx context.CLIContext, storeName, accStoreName string, decoder auth.AccountDecoder, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		addr, err := client.ResolveAddress(cliCtx, storeName, vars["address"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		delegation, err := client.QueryDelegation(cliCtx, storeName, accStoreName, decoder, addr)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query delegation. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(delegation)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Could't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
+
+// http request handler to query the feed of an account, paged with the
+// after and limit query parameters
+func feedHandlerFn(cliCtx context.CLIContext, storeName string, cdc *wire.Codec) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		addr, err := client.ResolveAddress(cliCtx, storeName, vars["address"])
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		var after client.FeedCursor
+		if s := r.URL.Query().Get("after"); s != "" {
+			after, err = client.ParseFeedCursor(s)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		limit := 20
+		if s := r.URL.Query().Get("limit"); s != "" {
+			limit, err = strconv.Atoi(s)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+
+		items, err := client.QueryFeed(cliCtx, storeName, addr, after, limit)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Couldn't query feed. Error: %s", err.Error())))
+			return
+		}
+
+		output, err := cdc.MarshalJSON(items)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(fmt.Sprintf("Could't marshall query result. Error: %s", err.Error())))
+			return
+		}
+
+		w.Write(output)
+	}
+}
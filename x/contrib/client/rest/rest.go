@@ -13,4 +13,8 @@ import (
 func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec, kb keys.Keybase) {
 	registerQueryRoutes(cliCtx, r, cdc)
 	registerTxRoutes(cliCtx, r, cdc, kb)
+	registerBountyRoutes(cliCtx, r, cdc, kb)
+	registerProfileRoutes(cliCtx, r, cdc, kb)
+	registerWebsocketRoutes(cliCtx, r, cdc)
+	registerOfflineRoutes(cliCtx, r, cdc)
 }
@@ -1,11 +1,9 @@
 package rest
 
 import (
-	"encoding/hex"
 	"encoding/json"
 	"io/ioutil"
 	"net/http"
-	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/gorilla/mux"
@@ -40,13 +38,17 @@ type contribBody struct {
 	Sequence         int64  `json:"sequence"`
 	AccountNumber    int64  `json:"account_number"`
 	Gas              int64  `json:"gas"`
-	Content          string `json:"content"`
-	Key              string `json:"key"`
-	Time             string `json:"time"`
+	Content          string `json:"content"`  // hex encoded
+	Message          string `json:"message"`  // plain text content, instead of content
+	Key              string `json:"key"`      // optional, derived from the contrib by default
+	Time             string `json:"time"`     // optional, now by default
 	VoteType         string `json:"votetype"` // must provide if doing vote contrib. can ignore it if not vote
+	Tip              string `json:"tip"`      // optional coins sent to the recipient of a post or recommend
 }
 
 // ContribRequestHandlerFn - http request handler to send contrib.
+// Deprecated: it signs with a key of the LCD keybase and takes its password
+// over HTTP, wallets should use the unsigned/signed endpoints instead.
 func ContribRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CLIContext) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// collect data
@@ -54,7 +56,7 @@ func ContribRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CL
 		bech32addr := vars["address"]
 		ctbtype := vars["ctbtype"]
 
-		to, err := sdk.AccAddressFromBech32(bech32addr)
+		to, err := client.ResolveAddress(cliCtx, "contrib", bech32addr)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
@@ -89,52 +91,13 @@ func ContribRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CL
 		// 	return
 		// }
 
-		ctbTime, err := time.Parse(time.RFC3339, m.Time)
+		ctb, err := buildContrib(ctbtype, sdk.AccAddress(info.GetPubKey().Address()), to, m)
 		if err != nil {
 			w.WriteHeader(http.StatusBadRequest)
 			w.Write([]byte(err.Error()))
 			return
 		}
 
-		ctbContent, err := hex.DecodeString(m.Content)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(err.Error()))
-			return
-		}
-
-		key, err := hex.DecodeString(m.Key)
-		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(err.Error()))
-			return
-		}
-
-		var ctb contrib.Contrib
-		switch ctbtype {
-		case "invite":
-			ctb = contrib.Invite{contrib.BaseContrib2{contrib.BaseContrib{key, sdk.AccAddress(info.GetPubKey().Address()), ctbTime}, to}, ctbContent}
-		case "recommend":
-			ctb = contrib.Recommend{contrib.BaseContrib2{contrib.BaseContrib{key, sdk.AccAddress(info.GetPubKey().Address()), ctbTime}, to}, ctbContent}
-		case "post":
-			ctb = contrib.Post{contrib.BaseContrib2{contrib.BaseContrib{key, sdk.AccAddress(info.GetPubKey().Address()), ctbTime}, to}, ctbContent}
-		case "vote":
-			switch m.VoteType {
-			case "upvote":
-				ctb = contrib.Vote{contrib.BaseContrib3{contrib.BaseContrib{key, sdk.AccAddress(info.GetPubKey().Address()), ctbTime}, to, 1}, ctbContent}
-			case "downvote":
-				ctb = contrib.Vote{contrib.BaseContrib3{contrib.BaseContrib{key, sdk.AccAddress(info.GetPubKey().Address()), ctbTime}, to, -1}, ctbContent}
-			default:
-				w.WriteHeader(http.StatusBadRequest)
-				w.Write([]byte(err.Error()))
-				return
-			}
-		default:
-			w.WriteHeader(http.StatusBadRequest)
-			w.Write([]byte(err.Error()))
-			return
-		}
-
 		// build message
 		msg := client.BuildContribMsg(ctb)
 		if err != nil { // XXX rechecking same error ?
@@ -176,3 +139,16 @@ func ContribRequestHandlerFn(cdc *wire.Codec, kb keys.Keybase, cliCtx context.CL
 		w.Write(output)
 	}
 }
+
+// buildContrib builds the ctbtype contrib described by m
+func buildContrib(ctbtype string, from, to sdk.AccAddress, m contribBody) (contrib.Contrib, error) {
+	return client.BuildContrib(client.ContribInput{
+		Type:    ctbtype,
+		Key:     m.Key,
+		Content: m.Content,
+		Message: m.Message,
+		Time:    m.Time,
+		Vote:    m.VoteType,
+		Tip:     m.Tip,
+	}, from, to)
+}
@@ -0,0 +1,319 @@
+package rest
+
+import (
+	"bytes"
+	gocontext "context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/gorilla/websocket"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	cmn "github.com/tendermint/tendermint/libs/common"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	"github.com/forbole/forboled/x/contrib"
+	"github.com/forbole/forboled/x/contrib/client"
+)
+
+// FlagWebsocketOrigins lists the origins besides the LCD itself allowed to
+// open websockets
+const FlagWebsocketOrigins = "ws-origins"
+
+const (
+	wsSubscriber   = "lcd-contribs"
+	wsSendBuffer   = 64
+	wsWriteTimeout = 10 * time.Second
+	wsPingPeriod   = 30 * time.Second
+)
+
+// ContribEvent is pushed to the websocket clients for every contrib of a
+// committed tx
+type ContribEvent struct {
+	Height      int64           `json:"height"`
+	TxHash      cmn.HexBytes    `json:"tx_hash"`
+	Type        string          `json:"type"`
+	Key         cmn.HexBytes    `json:"key"`
+	Contributor sdk.AccAddress  `json:"contributor"`
+	Recipient   sdk.AccAddress  `json:"recipient,omitempty"`
+	Contrib     contrib.Contrib `json:"contrib"`
+}
+
+type recipientContrib interface {
+	GetRecipient() sdk.AccAddress
+}
+
+// contribFilter keeps the events asked for by a websocket client, empty
+// fields match everything
+type contribFilter struct {
+	contributor sdk.AccAddress
+	recipient   sdk.AccAddress
+	ctbType     string
+}
+
+func (f contribFilter) match(ev ContribEvent) bool {
+	if f.contributor != nil && !bytes.Equal(f.contributor, ev.Contributor) {
+		return false
+	}
+	if f.recipient != nil && !bytes.Equal(f.recipient, ev.Recipient) {
+		return false
+	}
+	return f.ctbType == "" || strings.EqualFold(f.ctbType, ev.Type)
+}
+
+type wsClient struct {
+	filter contribFilter
+	send   chan ContribEvent
+}
+
+// contribHub subscribes once to the txs of the node and fans out the
+// contribs to the websocket clients
+type contribHub struct {
+	cliCtx   context.CLIContext
+	cdc      *wire.Codec
+	upgrader websocket.Upgrader
+
+	mtx     sync.Mutex
+	started bool
+	clients map[*wsClient]struct{}
+}
+
+func registerWebsocketRoutes(cliCtx context.CLIContext, r *mux.Router, cdc *wire.Codec) {
+	hub := &contribHub{
+		cliCtx:   cliCtx,
+		cdc:      cdc,
+		upgrader: websocket.Upgrader{CheckOrigin: checkOrigin(viper.GetStringSlice(FlagWebsocketOrigins))},
+		clients:  make(map[*wsClient]struct{}),
+	}
+	r.HandleFunc("/ws/contribs", contribsWebsocketHandlerFn(hub)).Methods("GET")
+}
+
+// start subscribes to the tx events of the node the first time a client
+// connects
+func (h *contribHub) start() error {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.started {
+		return nil
+	}
+
+	if !h.cliCtx.Client.IsRunning() {
+		if err := h.cliCtx.Client.Start(); err != nil {
+			return err
+		}
+	}
+	out := make(chan interface{}, wsSendBuffer)
+	err := h.cliCtx.Client.Subscribe(gocontext.Background(), wsSubscriber, tmtypes.EventQueryTx, out)
+	if err != nil {
+		return err
+	}
+	h.started = true
+
+	go h.run(out)
+	return nil
+}
+
+func (h *contribHub) run(out <-chan interface{}) {
+	decoder := auth.DefaultTxDecoder(h.cdc)
+	for data := range out {
+		var ev tmtypes.EventDataTx
+		switch data := data.(type) {
+		case tmtypes.EventDataTx:
+			ev = data
+		case *tmtypes.EventDataTx:
+			ev = *data
+		default:
+			continue
+		}
+		if ev.Result.IsErr() {
+			continue
+		}
+
+		tx, err := decoder(ev.Tx)
+		if err != nil {
+			continue
+		}
+		for _, msg := range tx.GetMsgs() {
+			msg, ok := msg.(contrib.MsgContrib)
+			if !ok {
+				continue
+			}
+			for _, ctb := range msg.Contribs {
+				h.broadcast(newContribEvent(ev, ctb))
+			}
+		}
+	}
+
+	// the node went away, the next client subscribes again
+	h.mtx.Lock()
+	h.started = false
+	for c := range h.clients {
+		close(c.send)
+		delete(h.clients, c)
+	}
+	h.mtx.Unlock()
+}
+
+func newContribEvent(ev tmtypes.EventDataTx, ctb contrib.Contrib) ContribEvent {
+	cev := ContribEvent{
+		Height:      ev.Height,
+		TxHash:      ev.Tx.Hash(),
+		Type:        contrib.ContribType(ctb),
+		Key:         ctb.GetKey(),
+		Contributor: ctb.GetContributor(),
+		Contrib:     ctb,
+	}
+	if rc, ok := ctb.(recipientContrib); ok {
+		cev.Recipient = rc.GetRecipient()
+	}
+	return cev
+}
+
+// broadcast sends ev to the matching clients, dropping the clients too slow
+// to keep up
+func (h *contribHub) broadcast(ev ContribEvent) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	for c := range h.clients {
+		if !c.filter.match(ev) {
+			continue
+		}
+		select {
+		case c.send <- ev:
+		default:
+			close(c.send)
+			delete(h.clients, c)
+		}
+	}
+}
+
+func (h *contribHub) add(c *wsClient) {
+	h.mtx.Lock()
+	h.clients[c] = struct{}{}
+	h.mtx.Unlock()
+}
+
+func (h *contribHub) remove(c *wsClient) {
+	h.mtx.Lock()
+	if _, ok := h.clients[c]; ok {
+		close(c.send)
+		delete(h.clients, c)
+	}
+	h.mtx.Unlock()
+}
+
+// checkOrigin allows the websockets opened from the pages of the LCD itself,
+// from the given origins, or from every origin with *. Clients sending no
+// origin are not browsers and are always allowed.
+func checkOrigin(origins []string) func(r *http.Request) bool {
+	return func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		if origin == "" {
+			return true
+		}
+		if u, err := url.Parse(origin); err == nil && strings.EqualFold(u.Host, r.Host) {
+			return true
+		}
+		for _, allowed := range origins {
+			if allowed == "*" || strings.EqualFold(strings.TrimSuffix(allowed, "/"), origin) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// websocket handler pushing the contribs of the committed txs, filtered
+// with the contributor, recipient and type query parameters
+func contribsWebsocketHandlerFn(hub *contribHub) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var filter contribFilter
+		var err error
+		query := r.URL.Query()
+		if s := query.Get("contributor"); s != "" {
+			filter.contributor, err = client.ResolveAddress(hub.cliCtx, "contrib", s)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+		if s := query.Get("recipient"); s != "" {
+			filter.recipient, err = client.ResolveAddress(hub.cliCtx, "contrib", s)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(err.Error()))
+				return
+			}
+		}
+		filter.ctbType = query.Get("type")
+
+		err = hub.start()
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			w.Write([]byte(err.Error()))
+			return
+		}
+
+		// the upgrader writes the error response itself
+		conn, err := hub.upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			return
+		}
+
+		c := &wsClient{filter: filter, send: make(chan ContribEvent, wsSendBuffer)}
+		hub.add(c)
+		go readLoop(hub, c, conn)
+		writeLoop(hub.cdc, c, conn)
+	}
+}
+
+// readLoop discards the messages of the client and unregisters it once the
+// connection is closed
+func readLoop(hub *contribHub, c *wsClient, conn *websocket.Conn) {
+	for {
+		if _, _, err := conn.NextReader(); err != nil {
+			hub.remove(c)
+			return
+		}
+	}
+}
+
+func writeLoop(cdc *wire.Codec, c *wsClient, conn *websocket.Conn) {
+	ticker := time.NewTicker(wsPingPeriod)
+	defer func() {
+		ticker.Stop()
+		conn.Close()
+	}()
+
+	for {
+		select {
+		case ev, ok := <-c.send:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if !ok {
+				conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			bz, err := cdc.MarshalJSON(ev)
+			if err != nil {
+				return
+			}
+			if err := conn.WriteMessage(websocket.TextMessage, bz); err != nil {
+				return
+			}
+		case <-ticker.C:
+			conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}
@@ -1,12 +1,128 @@
 package client
 
 import (
+	"fmt"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/forbole/forboled/types"
 	"github.com/forbole/forboled/x/contrib"
 )
 
+// StoreName is the name of the contrib store, which also holds the profile
+// names resolved by ResolveAddress
+const StoreName = "contrib"
+
 // build the contribTx msg
 func BuildContribMsg(ctb contrib.Contrib) sdk.Msg {
 	msg := contrib.NewMsgContrib(contrib.Contribs{ctb})
 	return msg
 }
+
+// Delegation is the repute delegation graph around an account
+type Delegation struct {
+	Address      sdk.AccAddress   `json:"address"`
+	Delegatee    sdk.AccAddress   `json:"delegatee"`
+	Delegators   []sdk.AccAddress `json:"delegators"`
+	VotingWeight int64            `json:"voting_weight"`
+}
+
+// QueryDelegation queries the delegation of addr and computes its voting
+// weight the same way as Keeper.VotingWeight
+func QueryDelegation(cliCtx context.CLIContext, storeName, accStoreName string, decoder auth.AccountDecoder, addr sdk.AccAddress) (d Delegation, err error) {
+	d.Address = addr
+	res, err := QueryStore(cliCtx, contrib.GetDelegationKey(addr), storeName)
+	if err != nil {
+		return d, err
+	}
+	if len(res) > 0 {
+		d.Delegatee = sdk.AccAddress(res)
+	}
+
+	d.Delegators, err = queryDelegators(cliCtx, storeName, addr)
+	if err != nil {
+		return d, err
+	}
+
+	if d.Delegatee == nil {
+		d.VotingWeight, err = queryDelegatedRepute(cliCtx, storeName, accStoreName, decoder, addr, d.Delegators)
+	}
+	return d, err
+}
+
+// QueryVoteTally queries the tally of the weighted votes on the contribs of
+// recipient
+func QueryVoteTally(cliCtx context.CLIContext, storeName string, recipient sdk.AccAddress) (tally contrib.VoteTally, err error) {
+	res, err := QueryStore(cliCtx, contrib.GetVoteTallyKey(recipient), storeName)
+	if err != nil || len(res) == 0 {
+		return tally, err
+	}
+	err = cliCtx.Codec.UnmarshalBinary(res, &tally)
+	return tally, err
+}
+
+func queryDelegators(cliCtx context.CLIContext, storeName string, delegatee sdk.AccAddress) ([]sdk.AccAddress, error) {
+	prefix := contrib.GetDelegatorsKey(delegatee)
+	kvs, err := cliCtx.QuerySubspace(prefix, storeName)
+	if err != nil {
+		return nil, err
+	}
+	delegators := make([]sdk.AccAddress, len(kvs))
+	for i, kv := range kvs {
+		delegators[i] = sdk.AccAddress(kv.Key[len(prefix):])
+	}
+	return delegators, nil
+}
+
+func queryDelegatedRepute(cliCtx context.CLIContext, storeName, accStoreName string, decoder auth.AccountDecoder, addr sdk.AccAddress, delegators []sdk.AccAddress) (int64, error) {
+	var weight int64
+	res, err := QueryStore(cliCtx, auth.AddressStoreKey(addr), accStoreName)
+	if err != nil {
+		return 0, err
+	}
+	if len(res) > 0 {
+		acc, err := decoder(res)
+		if err != nil {
+			return 0, err
+		}
+		if racc, ok := acc.(*types.ReputeAccount); ok && racc.GetRepute() > 0 {
+			weight = racc.GetRepute()
+		}
+	}
+
+	for _, delegator := range delegators {
+		next, err := queryDelegators(cliCtx, storeName, delegator)
+		if err != nil {
+			return 0, err
+		}
+		w, err := queryDelegatedRepute(cliCtx, storeName, accStoreName, decoder, delegator, next)
+		if err != nil {
+			return 0, err
+		}
+		weight += w
+	}
+	return weight, nil
+}
+
+// ResolveAddress parses s as a bech32 address, falling back to the owner of
+// the profile name s
+func ResolveAddress(cliCtx context.CLIContext, storeName, s string) (sdk.AccAddress, error) {
+	addr, err := sdk.AccAddressFromBech32(s)
+	if err == nil {
+		return addr, nil
+	}
+	if contrib.ValidateName(s) != nil {
+		return nil, err
+	}
+
+	res, qerr := QueryStore(cliCtx, contrib.GetNameKey(s), storeName)
+	if qerr != nil {
+		return nil, qerr
+	}
+	if len(res) == 0 {
+		return nil, fmt.Errorf("no account named %s", s)
+	}
+	return sdk.AccAddress(res), nil
+}
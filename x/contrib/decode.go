@@ -0,0 +1,54 @@
+package contrib
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// DecodeStoreValue decodes a value of the contrib store by its key, for the
+// tools reading the store directly. It returns false for the index keys,
+// which hold no value, and for the values it cannot decode.
+func DecodeStoreValue(cdc *wire.Codec, key, value []byte) (interface{}, bool) {
+	if len(key) == 0 {
+		return nil, false
+	}
+	if key[0] != ReservedKeyPrefix {
+		var status Status
+		if cdc.UnmarshalBinaryBare(value, &status) != nil {
+			return nil, false
+		}
+		return status, true
+	}
+
+	var v interface{}
+	switch {
+	case bytes.HasPrefix(key, reservedStatusPrefix):
+		var status Status
+		if cdc.UnmarshalBinaryBare(value, &status) != nil {
+			return nil, false
+		}
+		return status, true
+	case bytes.HasPrefix(key, delegationKeyPrefix), bytes.HasPrefix(key, nameKeyPrefix):
+		return sdk.AccAddress(value), true
+	case bytes.HasPrefix(key, freeQuotaKeyPrefix):
+		v = &FreeQuota{}
+	case bytes.HasPrefix(key, bountyIDKey):
+		v = new(int64)
+	case bytes.HasPrefix(key, bountyKeyPrefix):
+		v = &Bounty{}
+	case bytes.HasPrefix(key, reputeLedgerKeyPrefix):
+		v = &ReputeEntry{}
+	case bytes.HasPrefix(key, reputeLedgerBoundsKeyPrefix):
+		v = &reputeLedgerBounds{}
+	case bytes.HasPrefix(key, voteTallyKeyPrefix):
+		v = &VoteTally{}
+	default:
+		return nil, false
+	}
+	if cdc.UnmarshalBinary(value, v) != nil {
+		return nil, false
+	}
+	return v, true
+}
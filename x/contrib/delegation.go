@@ -0,0 +1,245 @@
+package contrib
+
+import (
+	"bytes"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	delegationKeyPrefix = []byte{ReservedKeyPrefix, 0x02} // delegator -> delegatee
+	delegatorKeyPrefix  = []byte{ReservedKeyPrefix, 0x03} // delegatee, delegator -> nil
+)
+
+// GetDelegationKey returns the store key of the delegation of a delegator
+func GetDelegationKey(delegator sdk.AccAddress) []byte {
+	return append(delegationKeyPrefix, delegator.Bytes()...)
+}
+
+// GetDelegatorsKey returns the prefix of the delegators of a delegatee
+func GetDelegatorsKey(delegatee sdk.AccAddress) []byte {
+	return append(delegatorKeyPrefix, delegatee.Bytes()...)
+}
+
+// GetDelegatorKey returns the store key indexing delegator under delegatee
+func GetDelegatorKey(delegatee, delegator sdk.AccAddress) []byte {
+	return append(GetDelegatorsKey(delegatee), delegator.Bytes()...)
+}
+
+// ReputeDelegation lends the voting weight of Delegator to Delegatee
+type ReputeDelegation struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Delegatee sdk.AccAddress `json:"delegatee"`
+}
+
+// IterateDelegations iterates over the delegations in delegator order
+func (k Keeper) IterateDelegations(ctx sdk.Context, process func(ReputeDelegation) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), delegationKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		delegation := ReputeDelegation{
+			Delegator: sdk.AccAddress(iter.Key()[len(delegationKeyPrefix):]),
+			Delegatee: sdk.AccAddress(iter.Value()),
+		}
+		if process(delegation) {
+			return
+		}
+	}
+}
+
+// GetDelegatee returns the account delegator lends its voting weight to
+func (k Keeper) GetDelegatee(ctx sdk.Context, delegator sdk.AccAddress) sdk.AccAddress {
+	bz := ctx.KVStore(k.storeKey).Get(GetDelegationKey(delegator))
+	if bz == nil {
+		return nil
+	}
+	return sdk.AccAddress(bz)
+}
+
+// GetDelegators returns the accounts delegating directly to delegatee
+func (k Keeper) GetDelegators(ctx sdk.Context, delegatee sdk.AccAddress) (delegators []sdk.AccAddress) {
+	prefix := GetDelegatorsKey(delegatee)
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		delegators = append(delegators, sdk.AccAddress(iter.Key()[len(prefix):]))
+	}
+	return delegators
+}
+
+// DelegateRepute lends the voting weight of delegator, and of everyone
+// delegating to it, to delegatee. The repute itself stays with delegator.
+// The votes already cast by delegator and by the accounts gaining or losing
+// its weight are weighed again.
+func (k Keeper) DelegateRepute(ctx sdk.Context, delegator, delegatee sdk.AccAddress) sdk.Error {
+	if k.am.GetAccount(ctx, delegatee) == nil {
+		return sdk.ErrUnknownAddress(delegatee.String())
+	}
+
+	// following the delegations of delegatee must not lead back to delegator
+	for addr := delegatee; addr != nil; addr = k.GetDelegatee(ctx, addr) {
+		if bytes.Equal(addr, delegator) {
+			return ErrDelegationCycle(DefaultCodespace, delegator, delegatee)
+		}
+	}
+
+	oldRoot := k.delegationRoot(ctx, delegator)
+	k.removeDelegation(ctx, delegator)
+	store := ctx.KVStore(k.storeKey)
+	store.Set(GetDelegationKey(delegator), delegatee.Bytes())
+	store.Set(GetDelegatorKey(delegatee, delegator), []byte{})
+
+	k.reweighVotes(ctx, delegator)
+	k.reweighVotes(ctx, oldRoot)
+	k.reweighVotes(ctx, k.delegationRoot(ctx, delegatee))
+	return nil
+}
+
+// UndelegateRepute revokes the delegation of delegator, its votes and the
+// ones of the account that had its weight are weighed again
+func (k Keeper) UndelegateRepute(ctx sdk.Context, delegator sdk.AccAddress) sdk.Error {
+	oldRoot := k.delegationRoot(ctx, delegator)
+	if !k.removeDelegation(ctx, delegator) {
+		return ErrDelegationNotFound(DefaultCodespace, delegator)
+	}
+	k.reweighVotes(ctx, delegator)
+	k.reweighVotes(ctx, oldRoot)
+	return nil
+}
+
+// delegationRoot follows the delegations of addr to the account voting with
+// its weight, addr itself if it does not delegate
+func (k Keeper) delegationRoot(ctx sdk.Context, addr sdk.AccAddress) sdk.AccAddress {
+	for delegatee := k.GetDelegatee(ctx, addr); delegatee != nil; delegatee = k.GetDelegatee(ctx, addr) {
+		addr = delegatee
+	}
+	return addr
+}
+
+func (k Keeper) removeDelegation(ctx sdk.Context, delegator sdk.AccAddress) bool {
+	delegatee := k.GetDelegatee(ctx, delegator)
+	if delegatee == nil {
+		return false
+	}
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(GetDelegationKey(delegator))
+	store.Delete(GetDelegatorKey(delegatee, delegator))
+	return true
+}
+
+// VotingWeight returns the weight of the votes of addr on contribs: its own
+// positive repute plus that of everyone delegating to it, directly or
+// through others. Accounts that delegate have no weight of their own. Only
+// the Vote contribs are weighed, the gov proposals are still tallied by stake.
+func (k Keeper) VotingWeight(ctx sdk.Context, addr sdk.AccAddress) int64 {
+	if k.GetDelegatee(ctx, addr) != nil {
+		return 0
+	}
+	return k.delegatedRepute(ctx, addr)
+}
+
+// delegatedRepute terminates as DelegateRepute keeps the graph acyclic
+func (k Keeper) delegatedRepute(ctx sdk.Context, addr sdk.AccAddress) int64 {
+	weight := k.getRepute(ctx, addr)
+	if weight < 0 {
+		weight = 0
+	}
+	for _, delegator := range k.GetDelegators(ctx, addr) {
+		weight += k.delegatedRepute(ctx, delegator)
+	}
+	return weight
+}
+
+var (
+	voteTallyKeyPrefix = []byte{ReservedKeyPrefix, 0x0D} // recipient -> tally
+	voterKeyPrefix     = []byte{ReservedKeyPrefix, 0x10} // voter, key -> nil
+)
+
+// GetVoteTallyKey returns the store key of the tally of the votes on the
+// contribs of recipient
+func GetVoteTallyKey(recipient sdk.AccAddress) []byte {
+	return append(voteTallyKeyPrefix, recipient.Bytes()...)
+}
+
+// VoteTally sums the voting weight of the up and down votes on the contribs
+// of an account
+type VoteTally struct {
+	Up   int64 `json:"up"`
+	Down int64 `json:"down"`
+}
+
+// GetVoteTally returns the tally of the votes on the contribs of recipient
+func (k Keeper) GetVoteTally(ctx sdk.Context, recipient sdk.AccAddress) (tally VoteTally) {
+	bz := ctx.KVStore(k.storeKey).Get(GetVoteTallyKey(recipient))
+	if bz != nil {
+		k.cdc.MustUnmarshalBinary(bz, &tally)
+	}
+	return tally
+}
+
+// GetVotesKey returns the prefix of the votes cast by voter
+func GetVotesKey(voter sdk.AccAddress) []byte {
+	return append(voterKeyPrefix, voter.Bytes()...)
+}
+
+// GetVoterKey returns the store key indexing the vote key under voter
+func GetVoterKey(voter sdk.AccAddress, key []byte) []byte {
+	return append(GetVotesKey(voter), key...)
+}
+
+func (k Keeper) setVoteTally(ctx sdk.Context, recipient sdk.AccAddress, tally VoteTally) {
+	ctx.KVStore(k.storeKey).Set(GetVoteTallyKey(recipient), k.cdc.MustMarshalBinary(tally))
+}
+
+// tallyVote weighs status, the updated vote stored under key, with the
+// current voting weight of its contributor and replaces old in the tally of
+// the recipient. The vote keeps this weight until a delegation changes the
+// weight of its contributor, repute changes do not weigh it again.
+func (k Keeper) tallyVote(ctx sdk.Context, key []byte, old VoteStatus, status *VoteStatus) {
+	status.Weight = k.VotingWeight(ctx, status.Contributor)
+	k.replaceVote(ctx, old, status)
+	ctx.KVStore(k.storeKey).Set(GetVoterKey(status.Contributor, key), []byte{})
+}
+
+// reweighVotes weighs the votes of voter with its current voting weight, so
+// that a delegated weight is never counted in the votes of both accounts
+func (k Keeper) reweighVotes(ctx sdk.Context, voter sdk.AccAddress) {
+	store := ctx.KVStore(k.storeKey)
+	prefix := GetVotesKey(voter)
+	var keys [][]byte
+	iter := sdk.KVStorePrefixIterator(store, prefix)
+	for ; iter.Valid(); iter.Next() {
+		keys = append(keys, iter.Key()[len(prefix):])
+	}
+	iter.Close()
+
+	weight := k.VotingWeight(ctx, voter)
+	for _, key := range keys {
+		status, _ := getStatus(store, key, k.cdc)
+		vote := status.(*VoteStatus)
+		if vote.Weight == weight {
+			continue
+		}
+		old := *vote
+		vote.Weight = weight
+		k.replaceVote(ctx, old, vote)
+		setStatus(store, key, vote, k.cdc)
+	}
+}
+
+// replaceVote replaces old with status in the tally of the recipient
+func (k Keeper) replaceVote(ctx sdk.Context, old VoteStatus, status *VoteStatus) {
+	tally := k.GetVoteTally(ctx, status.Recipient)
+	tally.add(old.Vote, -old.Weight)
+	tally.add(status.Vote, status.Weight)
+	k.setVoteTally(ctx, status.Recipient, tally)
+}
+
+func (tally *VoteTally) add(vote, weight int64) {
+	switch {
+	case vote > 0:
+		tally.Up += weight
+	case vote < 0:
+		tally.Down += weight
+	}
+}
@@ -0,0 +1,223 @@
+package contrib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+var (
+	addr3 = sdk.AccAddress(tmhash.Sum([]byte("addr3")))
+	addr4 = sdk.AccAddress(tmhash.Sum([]byte("addr4")))
+)
+
+func TestVotingWeight(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 10, nil)
+	setAccount(ctx, am, addr2, 20, nil)
+	setAccount(ctx, am, addr3, 30, nil)
+	setAccount(ctx, am, addr4, -40, nil)
+
+	// addr3 -> addr2 -> addr1 <- addr4
+	require.Nil(t, k.DelegateRepute(ctx, addr3, addr2))
+	require.Nil(t, k.DelegateRepute(ctx, addr2, addr1))
+	require.Nil(t, k.DelegateRepute(ctx, addr4, addr1))
+
+	cases := []struct {
+		name   string
+		addr   sdk.AccAddress
+		weight int64
+	}{
+		{"chained delegators, negative repute counts as 0", addr1, 60},
+		{"delegating account", addr2, 0},
+		{"delegating leaf", addr3, 0},
+		{"negative repute", addr4, 0},
+	}
+	for _, tc := range cases {
+		require.Equal(t, tc.weight, k.VotingWeight(ctx, tc.addr), tc.name)
+	}
+	require.Equal(t, addr1, k.GetDelegatee(ctx, addr2))
+	require.Len(t, k.GetDelegators(ctx, addr1), 2)
+}
+
+func TestUndelegateRepute(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 10, nil)
+	setAccount(ctx, am, addr2, 20, nil)
+	setAccount(ctx, am, addr3, 30, nil)
+
+	require.Nil(t, k.DelegateRepute(ctx, addr2, addr1))
+	require.Equal(t, int64(30), k.VotingWeight(ctx, addr1))
+
+	// delegating again moves the weight
+	require.Nil(t, k.DelegateRepute(ctx, addr2, addr3))
+	require.Equal(t, int64(10), k.VotingWeight(ctx, addr1))
+	require.Equal(t, int64(50), k.VotingWeight(ctx, addr3))
+	require.Empty(t, k.GetDelegators(ctx, addr1))
+
+	require.Nil(t, k.UndelegateRepute(ctx, addr2))
+	require.Nil(t, k.GetDelegatee(ctx, addr2))
+	require.Empty(t, k.GetDelegators(ctx, addr3))
+	require.Equal(t, int64(20), k.VotingWeight(ctx, addr2))
+	require.Equal(t, int64(30), k.VotingWeight(ctx, addr3))
+
+	err := k.UndelegateRepute(ctx, addr2)
+	require.NotNil(t, err)
+	require.Equal(t, CodeNoDelegation, err.Code())
+}
+
+func TestDelegateReputeRejected(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 10, nil)
+	setAccount(ctx, am, addr2, 20, nil)
+	setAccount(ctx, am, addr3, 30, nil)
+
+	require.Nil(t, k.DelegateRepute(ctx, addr1, addr2))
+	require.Nil(t, k.DelegateRepute(ctx, addr2, addr3))
+
+	cases := []struct {
+		name      string
+		delegator sdk.AccAddress
+		delegatee sdk.AccAddress
+		code      sdk.CodeType
+	}{
+		{"unknown account to itself", addr4, addr4, sdk.CodeUnknownAddress},
+		{"unknown delegatee", addr1, addr4, sdk.CodeUnknownAddress},
+		{"delegation to itself", addr3, addr3, CodeDelegationCycle},
+		{"direct cycle", addr2, addr1, CodeDelegationCycle},
+		{"3-way cycle", addr3, addr1, CodeDelegationCycle},
+	}
+	for _, tc := range cases {
+		err := k.DelegateRepute(ctx, tc.delegator, tc.delegatee)
+		require.NotNil(t, err, tc.name)
+		require.Equal(t, tc.code, err.Code(), tc.name)
+	}
+
+	// the rejected delegations left the graph unchanged
+	require.Equal(t, addr2, k.GetDelegatee(ctx, addr1))
+	require.Equal(t, addr3, k.GetDelegatee(ctx, addr2))
+	require.Nil(t, k.GetDelegatee(ctx, addr3))
+	require.Equal(t, int64(60), k.VotingWeight(ctx, addr3))
+}
+
+func TestVoteTally(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 10, nil)
+	setAccount(ctx, am, addr2, 20, nil)
+	setAccount(ctx, am, addr3, 30, nil)
+	setAccount(ctx, am, addr4, 0, nil)
+	require.Nil(t, k.DelegateRepute(ctx, addr2, addr1))
+
+	steps := []struct {
+		name  string
+		vote  *Vote
+		tally VoteTally
+	}{
+		{"up vote with the delegated weight", newVote("vote1", addr1, addr4, genesisTime, 1), VoteTally{Up: 30}},
+		{"down vote", newVote("vote3", addr3, addr4, genesisTime, -1), VoteTally{Up: 30, Down: 30}},
+		{"delegating voter has no weight", newVote("vote2", addr2, addr4, genesisTime, 1), VoteTally{Up: 30, Down: 30}},
+		// the votes of addr1 and addr2 each earned them 1 repute
+		{"changed to a down vote", newVote("vote1", addr1, addr4, genesisTime.Add(time.Minute), -1), VoteTally{Down: 62}},
+		{"cancelled vote", newVote("vote3", addr3, addr4, genesisTime.Add(time.Minute), -1), VoteTally{Down: 32}},
+	}
+	for _, step := range steps {
+		tags := sdk.EmptyTags()
+		_, err := k.UpdateContrib(ctx, step.vote, &tags)
+		require.Nil(t, err, step.name)
+		require.Equal(t, step.tally, k.GetVoteTally(ctx, addr4), step.name)
+	}
+
+	// undelegating moves the weight of addr2 back to its own vote, the
+	// changed vote earned addr1 another repute
+	require.Nil(t, k.UndelegateRepute(ctx, addr2))
+	require.Equal(t, VoteTally{Up: 21, Down: 12}, k.GetVoteTally(ctx, addr4))
+	tags := sdk.EmptyTags()
+	_, err := k.UpdateContrib(ctx, newVote("vote1", addr1, addr4, genesisTime.Add(2*time.Minute), -1), &tags)
+	require.Nil(t, err)
+	require.Equal(t, VoteTally{Up: 21}, k.GetVoteTally(ctx, addr4))
+}
+
+func getVoteWeight(ctx sdk.Context, k Keeper, key string) int64 {
+	status, _ := getStatus(ctx.KVStore(k.storeKey), []byte(key), k.cdc)
+	return status.(*VoteStatus).Weight
+}
+
+// a delegated weight is counted once, in the votes of the delegatee
+func TestVoteThenDelegate(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 10, nil)
+	setAccount(ctx, am, addr2, 20, nil)
+	setAccount(ctx, am, addr3, 30, nil)
+	setAccount(ctx, am, addr4, 0, nil)
+
+	for i, voter := range []sdk.AccAddress{addr1, addr2, addr3} {
+		tags := sdk.EmptyTags()
+		_, err := k.UpdateContrib(ctx, newVote(fmt.Sprintf("vote%d", i+1), voter, addr4, genesisTime, 1), &tags)
+		require.Nil(t, err)
+	}
+	require.Equal(t, VoteTally{Up: 60}, k.GetVoteTally(ctx, addr4))
+
+	// each vote earned its voter 1 repute, a vote is weighed again only when
+	// the delegations of its voter change
+	steps := []struct {
+		name    string
+		update  func() sdk.Error
+		weights []int64
+	}{
+		{"delegation", func() sdk.Error { return k.DelegateRepute(ctx, addr1, addr2) }, []int64{0, 32, 30}},
+		{"chained delegation", func() sdk.Error { return k.DelegateRepute(ctx, addr2, addr3) }, []int64{0, 0, 63}},
+		{"redelegation", func() sdk.Error { return k.DelegateRepute(ctx, addr1, addr3) }, []int64{0, 0, 63}},
+		{"undelegation", func() sdk.Error { return k.UndelegateRepute(ctx, addr2) }, []int64{0, 21, 42}},
+		{"undelegation of the chain", func() sdk.Error { return k.UndelegateRepute(ctx, addr1) }, []int64{11, 21, 31}},
+	}
+	for _, step := range steps {
+		require.Nil(t, step.update(), step.name)
+		var total int64
+		for i, weight := range step.weights {
+			require.Equal(t, weight, getVoteWeight(ctx, k, fmt.Sprintf("vote%d", i+1)), step.name)
+			total += weight
+		}
+		require.Equal(t, VoteTally{Up: total}, k.GetVoteTally(ctx, addr4), step.name)
+	}
+}
+
+func TestGenesisDelegations(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	for _, addr := range []sdk.AccAddress{addr1, addr2, addr3} {
+		setAccount(ctx, am, addr, 10, nil)
+	}
+	require.Nil(t, k.DelegateRepute(ctx, addr1, addr2))
+	require.Nil(t, k.DelegateRepute(ctx, addr2, addr3))
+	data := WriteGenesis(ctx, k)
+	require.Len(t, data.Delegations, 2)
+
+	cases := []struct {
+		name        string
+		delegations []ReputeDelegation
+		valid       bool
+	}{
+		{"exported delegations", data.Delegations, true},
+		{"cycle", append(data.Delegations, ReputeDelegation{Delegator: addr3, Delegatee: addr1}), false},
+	}
+	for _, tc := range cases {
+		ctx, am, k, setter := createTestInput(t)
+		for _, addr := range []sdk.AccAddress{addr1, addr2, addr3} {
+			setAccount(ctx, am, addr, 10, nil)
+		}
+		data.Delegations = tc.delegations
+
+		initGenesis := func() { InitGenesis(ctx, k, setter, data) }
+		if !tc.valid {
+			require.Panics(t, initGenesis, tc.name)
+			continue
+		}
+		require.NotPanics(t, initGenesis, tc.name)
+		require.Equal(t, tc.delegations, WriteGenesis(ctx, k).Delegations, tc.name)
+		require.Equal(t, int64(30), k.VotingWeight(ctx, addr3), tc.name)
+	}
+}
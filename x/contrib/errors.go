@@ -2,6 +2,8 @@
 package contrib
 
 import (
+	"fmt"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
 
@@ -16,6 +18,14 @@ const (
 	CodeInvalidInput     sdk.CodeType = 901
 	CodeInvalidOutput    sdk.CodeType = 902
 	CodeInvalidContrib   sdk.CodeType = 903
+	CodeContentTooLarge  sdk.CodeType = 904
+	CodeInsufficientFee  sdk.CodeType = 905
+	CodeDelegationCycle  sdk.CodeType = 906
+	CodeNoDelegation     sdk.CodeType = 907
+	CodeInvalidBounty    sdk.CodeType = 908
+	CodeBountyNotFound   sdk.CodeType = 909
+	CodeInvalidProfile   sdk.CodeType = 910
+	CodeNameTaken        sdk.CodeType = 911
 )
 
 // NOTE: Don't stringer this, we'll put better messages in later.
@@ -27,6 +37,22 @@ func codeToDefaultMsg(code sdk.CodeType) string {
 		return "Invalid output coins"
 	case CodeInvalidContrib:
 		return "Invalid contrib"
+	case CodeContentTooLarge:
+		return "Contrib content too large"
+	case CodeInsufficientFee:
+		return "Insufficient fee"
+	case CodeDelegationCycle:
+		return "Repute delegation cycle"
+	case CodeNoDelegation:
+		return "No repute delegation"
+	case CodeInvalidBounty:
+		return "Invalid bounty"
+	case CodeBountyNotFound:
+		return "Bounty not found"
+	case CodeInvalidProfile:
+		return "Invalid profile"
+	case CodeNameTaken:
+		return "Name already taken"
 	default:
 		return sdk.CodeToDefaultMsg(code)
 	}
@@ -74,6 +100,38 @@ func ErrNoContribs(codespace sdk.CodespaceType) sdk.Error {
 	return newError(codespace, CodeInvalidContrib, "")
 }
 
+func ErrContentTooLarge(codespace sdk.CodespaceType, size, maxSize int) sdk.Error {
+	return newError(codespace, CodeContentTooLarge, fmt.Sprintf("content is %d bytes, maximum is %d", size, maxSize))
+}
+
+func ErrInsufficientFee(codespace sdk.CodespaceType, minFee sdk.Int, denom string) sdk.Error {
+	return newError(codespace, CodeInsufficientFee, fmt.Sprintf("fee must be at least %v%s", minFee, denom))
+}
+
+func ErrDelegationCycle(codespace sdk.CodespaceType, delegator, delegatee sdk.AccAddress) sdk.Error {
+	return newError(codespace, CodeDelegationCycle, fmt.Sprintf("%v already delegates to %v", delegatee, delegator))
+}
+
+func ErrDelegationNotFound(codespace sdk.CodespaceType, delegator sdk.AccAddress) sdk.Error {
+	return newError(codespace, CodeNoDelegation, fmt.Sprintf("%v does not delegate its repute", delegator))
+}
+
+func ErrInvalidBounty(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidBounty, msg)
+}
+
+func ErrBountyNotFound(codespace sdk.CodespaceType, id int64) sdk.Error {
+	return newError(codespace, CodeBountyNotFound, fmt.Sprintf("bounty %d not found", id))
+}
+
+func ErrInvalidProfile(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidProfile, msg)
+}
+
+func ErrNameTaken(codespace sdk.CodespaceType, name string) sdk.Error {
+	return newError(codespace, CodeNameTaken, fmt.Sprintf("name %s is already taken", name))
+}
+
 //----------------------------------------
 
 func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
@@ -0,0 +1,249 @@
+package contrib
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+var (
+	followingKeyPrefix = []byte{ReservedKeyPrefix, 0x07} // follower, followee -> nil
+	followerKeyPrefix  = []byte{ReservedKeyPrefix, 0x08} // followee, follower -> nil
+	postKeyPrefix      = []byte{ReservedKeyPrefix, 0x09} // author, time, key -> nil
+)
+
+// GetFollowingKey returns the prefix of the accounts followed by follower
+func GetFollowingKey(follower sdk.AccAddress) []byte {
+	return append(followingKeyPrefix, follower.Bytes()...)
+}
+
+// GetFollowersKey returns the prefix of the followers of followee
+func GetFollowersKey(followee sdk.AccAddress) []byte {
+	return append(followerKeyPrefix, followee.Bytes()...)
+}
+
+// GetPostsKey returns the prefix of the posts of author, ordered by time
+func GetPostsKey(author sdk.AccAddress) []byte {
+	return append(postKeyPrefix, author.Bytes()...)
+}
+
+// GetPostIndexKey returns the key indexing a post of author
+func GetPostIndexKey(author sdk.AccAddress, t time.Time, key []byte) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(t.Unix()))
+	return append(append(GetPostsKey(author), bz...), key...)
+}
+
+// ParsePostIndexKey returns the time and the contrib key of a post index key
+func ParsePostIndexKey(indexKey []byte) (time.Time, []byte) {
+	bz := indexKey[len(postKeyPrefix)+sdk.AddrLen:]
+	return time.Unix(int64(binary.BigEndian.Uint64(bz[:8])), 0).UTC(), bz[8:]
+}
+
+// the time then the key of a post index key, the order of the posts in a feed
+func postIndexPosition(indexKey []byte) []byte {
+	return indexKey[len(postKeyPrefix)+sdk.AddrLen:]
+}
+
+// updateHook is implemented by the contribs changing state besides their
+// status
+type updateHook interface {
+	afterUpdate(ctx sdk.Context, k Keeper, isNew bool)
+}
+
+type Follow struct {
+	BaseContrib2
+}
+
+func (ctb Follow) ValidateBasic() sdk.Error {
+	if err := ctb.BaseContrib2.ValidateBasic(); err != nil {
+		return err
+	}
+	if bytes.Equal(ctb.Contributor, ctb.Recipient) {
+		return sdk.ErrInvalidAddress("cannot follow yourself")
+	}
+	return nil
+}
+
+// following earns no repute
+func (ctb Follow) NewStatus() Status {
+	return &FollowStatus{BaseStatus: BaseStatus{Score: 0, Contributor: ctb.Contributor, Time: ctb.Time}, Recipient: ctb.Recipient}
+}
+
+func (ctb Follow) afterUpdate(ctx sdk.Context, k Keeper, isNew bool) {
+	k.setFollowing(ctx, Following{Follower: ctb.Contributor, Followee: ctb.Recipient})
+}
+
+type Unfollow struct {
+	BaseContrib2
+}
+
+func (ctb Unfollow) NewStatus() Status {
+	return &UnfollowStatus{BaseStatus: BaseStatus{Score: 0, Contributor: ctb.Contributor, Time: ctb.Time}, Recipient: ctb.Recipient}
+}
+
+func (ctb Unfollow) afterUpdate(ctx sdk.Context, k Keeper, isNew bool) {
+	store := ctx.KVStore(k.storeKey)
+	store.Delete(append(GetFollowingKey(ctb.Contributor), ctb.Recipient...))
+	store.Delete(append(GetFollowersKey(ctb.Recipient), ctb.Contributor...))
+}
+
+// the index only holds the posts created since it was introduced, the older
+// posts are not listed in the feeds
+func (ctb Post) afterUpdate(ctx sdk.Context, k Keeper, isNew bool) {
+	if isNew {
+		ctx.KVStore(k.storeKey).Set(GetPostIndexKey(ctb.Contributor, ctb.Time, ctb.Key), []byte{})
+	}
+}
+
+type FollowStatus BaseStatus2
+
+// a follow is recorded once, its key cannot be reused
+func (status *FollowStatus) Update(ctb Contrib) sdk.Error {
+	return ErrInvalidContrib(DefaultCodespace, "key already used")
+}
+
+type UnfollowStatus BaseStatus2
+
+func (status *UnfollowStatus) Update(ctb Contrib) sdk.Error {
+	return ErrInvalidContrib(DefaultCodespace, "key already used")
+}
+
+// Following is an account Follower follows
+type Following struct {
+	Follower sdk.AccAddress `json:"follower"`
+	Followee sdk.AccAddress `json:"followee"`
+}
+
+func (k Keeper) setFollowing(ctx sdk.Context, f Following) {
+	store := ctx.KVStore(k.storeKey)
+	store.Set(append(GetFollowingKey(f.Follower), f.Followee...), []byte{})
+	store.Set(append(GetFollowersKey(f.Followee), f.Follower...), []byte{})
+}
+
+// IterateFollowings iterates over the follow graph in follower order
+func (k Keeper) IterateFollowings(ctx sdk.Context, process func(Following) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), followingKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		bz := iter.Key()[len(followingKeyPrefix):]
+		f := Following{
+			Follower: sdk.AccAddress(bz[:sdk.AddrLen]),
+			Followee: sdk.AccAddress(bz[sdk.AddrLen:]),
+		}
+		if process(f) {
+			return
+		}
+	}
+}
+
+// GetFollowing returns the accounts followed by follower
+func (k Keeper) GetFollowing(ctx sdk.Context, follower sdk.AccAddress) []sdk.AccAddress {
+	return k.getAddresses(ctx, GetFollowingKey(follower))
+}
+
+// GetFollowers returns the followers of followee
+func (k Keeper) GetFollowers(ctx sdk.Context, followee sdk.AccAddress) []sdk.AccAddress {
+	return k.getAddresses(ctx, GetFollowersKey(followee))
+}
+
+func (k Keeper) getAddresses(ctx sdk.Context, prefix []byte) (addrs []sdk.AccAddress) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), prefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		addrs = append(addrs, sdk.AccAddress(iter.Key()[len(prefix):]))
+	}
+	return addrs
+}
+
+// QueryFeedPath is the path of the app query returning a feed
+const QueryFeedPath = "/custom/contrib/feed"
+
+// MaxFeedLimit bounds the number of posts of a feed query
+const MaxFeedLimit = 100
+
+// FeedQuery asks for the posts of the accounts followed by Addr following
+// the post at Time with Key in the feed, or the latest posts when both are
+// zero. Limit is capped by MaxFeedLimit, which it defaults to.
+type FeedQuery struct {
+	Addr  sdk.AccAddress `json:"addr"`
+	Time  time.Time      `json:"time"`
+	Key   []byte         `json:"key"`
+	Limit int            `json:"limit"`
+}
+
+// FeedPost is a post listed in a feed
+type FeedPost struct {
+	Author sdk.AccAddress `json:"author"`
+	Time   time.Time      `json:"time"`
+	Key    []byte         `json:"key"`
+}
+
+// GetFeed returns the posts of the accounts followed by query.Addr ordered
+// by time then key, newest first. The post indexes of the followees are read
+// backwards from the cursor and merged, so a page reads at most its own posts
+// besides the next post of each followee.
+func (k Keeper) GetFeed(ctx sdk.Context, query FeedQuery) []FeedPost {
+	limit := query.Limit
+	if limit <= 0 || limit > MaxFeedLimit {
+		limit = MaxFeedLimit
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	var iters []sdk.Iterator
+	defer func() {
+		for _, iter := range iters {
+			iter.Close()
+		}
+	}()
+	for _, followee := range k.GetFollowing(ctx, query.Addr) {
+		prefix := GetPostsKey(followee)
+		end := sdk.PrefixEndBytes(prefix)
+		if !query.Time.IsZero() || len(query.Key) > 0 {
+			end = GetPostIndexKey(followee, query.Time, query.Key)
+		}
+		iters = append(iters, store.ReverseIterator(prefix, end))
+	}
+
+	var posts []FeedPost
+	for len(posts) < limit {
+		// the newest of the posts heading the indexes comes next
+		next := -1
+		for i, iter := range iters {
+			if !iter.Valid() {
+				continue
+			}
+			if next < 0 || bytes.Compare(postIndexPosition(iter.Key()), postIndexPosition(iters[next].Key())) > 0 {
+				next = i
+			}
+		}
+		if next < 0 {
+			break
+		}
+
+		indexKey := iters[next].Key()
+		t, key := ParsePostIndexKey(indexKey)
+		author := indexKey[len(postKeyPrefix) : len(postKeyPrefix)+sdk.AddrLen]
+		posts = append(posts, FeedPost{Author: sdk.AccAddress(author), Time: t, Key: key})
+		iters[next].Next()
+	}
+	return posts
+}
+
+// QueryFeed answers a query at QueryFeedPath, its data is a binary FeedQuery
+// and its value the binary FeedPosts
+func (k Keeper) QueryFeed(ctx sdk.Context, req abci.RequestQuery) abci.ResponseQuery {
+	var query FeedQuery
+	err := k.cdc.UnmarshalBinary(req.Data, &query)
+	if err != nil {
+		return sdk.ErrUnknownRequest(fmt.Sprintf("invalid feed query: %s", err)).QueryResult()
+	}
+	return abci.ResponseQuery{
+		Code:  uint32(sdk.ABCICodeOK),
+		Value: k.cdc.MustMarshalBinary(k.GetFeed(ctx, query)),
+	}
+}
@@ -0,0 +1,182 @@
+package contrib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func newFollow(key string, follower, followee sdk.AccAddress, t time.Time) *Follow {
+	return &Follow{BaseContrib2{BaseContrib{[]byte(key), follower, t}, followee}}
+}
+
+func newUnfollow(key string, follower, followee sdk.AccAddress, t time.Time) *Unfollow {
+	return &Unfollow{BaseContrib2{BaseContrib{[]byte(key), follower, t}, followee}}
+}
+
+func TestFollow(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	for _, addr := range []sdk.AccAddress{addr1, addr2, addr3} {
+		setAccount(ctx, am, addr, 0, nil)
+	}
+
+	require.NotNil(t, newFollow("self", addr1, addr1, genesisTime).ValidateBasic())
+
+	steps := []struct {
+		name      string
+		ctb       Contrib
+		code      sdk.CodeType
+		following []sdk.AccAddress
+		followers []sdk.AccAddress
+	}{
+		{"follow", newFollow("follow1", addr1, addr2, genesisTime), sdk.CodeOK, []sdk.AccAddress{addr2}, []sdk.AccAddress{addr1}},
+		{"second followee", newFollow("follow2", addr1, addr3, genesisTime), sdk.CodeOK, []sdk.AccAddress{addr2, addr3}, []sdk.AccAddress{addr1}},
+		{"reused follow key", newFollow("follow1", addr1, addr2, genesisTime.Add(time.Minute)), CodeInvalidContrib, []sdk.AccAddress{addr2, addr3}, []sdk.AccAddress{addr1}},
+		{"unfollow", newUnfollow("unfollow1", addr1, addr2, genesisTime), sdk.CodeOK, []sdk.AccAddress{addr3}, nil},
+		{"reused unfollow key", newUnfollow("unfollow1", addr1, addr3, genesisTime.Add(time.Minute)), CodeInvalidContrib, []sdk.AccAddress{addr3}, nil},
+		{"follow again", newFollow("follow3", addr1, addr2, genesisTime), sdk.CodeOK, []sdk.AccAddress{addr2, addr3}, []sdk.AccAddress{addr1}},
+	}
+	for _, step := range steps {
+		tags := sdk.EmptyTags()
+		_, err := k.UpdateContrib(ctx, step.ctb, &tags)
+		if step.code == sdk.CodeOK {
+			require.Nil(t, err, step.name)
+		} else {
+			require.NotNil(t, err, step.name)
+			require.Equal(t, step.code, err.Code(), step.name)
+		}
+		require.ElementsMatch(t, step.following, k.GetFollowing(ctx, addr1), step.name)
+		require.Equal(t, step.followers, k.GetFollowers(ctx, addr2), step.name)
+	}
+}
+
+func TestPostIndex(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, nil)
+	setAccount(ctx, am, addr2, 0, nil)
+
+	later := genesisTime.Add(time.Minute)
+	for _, post := range []*Post{
+		newPost("b", addr1, addr2, later),
+		newPost("a", addr1, addr2, genesisTime),
+		newPost("c", addr2, addr1, genesisTime),
+		// an updated post keeps its place in the index
+		newPost("a", addr1, addr2, later.Add(time.Minute)),
+	} {
+		tags := sdk.EmptyTags()
+		_, err := k.UpdateContrib(ctx, post, &tags)
+		require.Nil(t, err)
+	}
+
+	type indexed struct {
+		time time.Time
+		key  string
+	}
+	var posts []indexed
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetPostsKey(addr1))
+	for ; iter.Valid(); iter.Next() {
+		t, key := ParsePostIndexKey(iter.Key())
+		posts = append(posts, indexed{t, string(key)})
+	}
+	iter.Close()
+	require.Equal(t, []indexed{{genesisTime, "a"}, {later, "b"}}, posts)
+}
+
+func TestGetFeed(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	for _, addr := range []sdk.AccAddress{addr1, addr2, addr3, addr4} {
+		setAccount(ctx, am, addr, 0, nil)
+	}
+
+	tags := sdk.EmptyTags()
+	for i, followee := range []sdk.AccAddress{addr2, addr3} {
+		_, err := k.UpdateContrib(ctx, newFollow(fmt.Sprintf("follow%d", i), addr1, followee, genesisTime), &tags)
+		require.Nil(t, err)
+	}
+	// the posts of addr2 and addr3 interleave, two of them in the same second
+	posts := []struct {
+		key    string
+		author sdk.AccAddress
+		time   time.Time
+	}{
+		{"p1", addr2, genesisTime},
+		{"p2", addr3, genesisTime.Add(time.Minute)},
+		{"p3", addr2, genesisTime.Add(2 * time.Minute)},
+		{"p4", addr3, genesisTime.Add(2 * time.Minute)},
+		{"p5", addr4, genesisTime.Add(3 * time.Minute)},
+		{"p6", addr3, genesisTime.Add(4 * time.Minute)},
+	}
+	for _, post := range posts {
+		_, err := k.UpdateContrib(ctx, newPost(post.key, post.author, addr1, post.time), &tags)
+		require.Nil(t, err)
+	}
+
+	var all []string
+	for _, post := range k.GetFeed(ctx, FeedQuery{Addr: addr1}) {
+		all = append(all, string(post.Key))
+	}
+	require.Equal(t, []string{"p6", "p4", "p3", "p2", "p1"}, all)
+	require.Empty(t, k.GetFeed(ctx, FeedQuery{Addr: addr4}))
+
+	// the pages end at the cursor of their last post
+	var pages [][]string
+	query := FeedQuery{Addr: addr1, Limit: 2}
+	for {
+		feed := k.GetFeed(ctx, query)
+		if len(feed) == 0 {
+			break
+		}
+		var page []string
+		for _, post := range feed {
+			page = append(page, string(post.Key))
+		}
+		pages = append(pages, page)
+		last := feed[len(feed)-1]
+		query.Time, query.Key = last.Time, last.Key
+	}
+	require.Equal(t, [][]string{{"p6", "p4"}, {"p3", "p2"}, {"p1"}}, pages)
+
+	feed := k.GetFeed(ctx, FeedQuery{Addr: addr1, Limit: 1})
+	require.Equal(t, []FeedPost{{Author: addr3, Time: genesisTime.Add(4 * time.Minute), Key: []byte("p6")}}, feed)
+
+	// the query answers with the same feed
+	query = FeedQuery{Addr: addr1, Time: genesisTime.Add(2 * time.Minute), Key: []byte("p4"), Limit: 1}
+	res := k.QueryFeed(ctx, abci.RequestQuery{Path: QueryFeedPath, Data: k.cdc.MustMarshalBinary(query)})
+	require.True(t, res.IsOK())
+	var answer []FeedPost
+	k.cdc.MustUnmarshalBinary(res.Value, &answer)
+	require.Equal(t, k.GetFeed(ctx, query), answer)
+	require.Equal(t, []byte("p3"), answer[0].Key)
+
+	res = k.QueryFeed(ctx, abci.RequestQuery{Path: QueryFeedPath, Data: []byte("feed")})
+	require.False(t, res.IsOK())
+}
+
+func TestGenesisFollowings(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	for _, addr := range []sdk.AccAddress{addr1, addr2, addr3} {
+		setAccount(ctx, am, addr, 0, nil)
+	}
+	tags := sdk.EmptyTags()
+	for i, f := range []Following{{addr1, addr2}, {addr1, addr3}, {addr3, addr2}} {
+		_, err := k.UpdateContrib(ctx, newFollow(fmt.Sprintf("follow%d", i), f.Follower, f.Followee, genesisTime), &tags)
+		require.Nil(t, err)
+	}
+	data := WriteGenesis(ctx, k)
+	require.Len(t, data.Followings, 3)
+
+	ctx, _, k, setter := createTestInput(t)
+	InitGenesis(ctx, k, setter, data)
+	require.Equal(t, data.Followings, WriteGenesis(ctx, k).Followings)
+	require.ElementsMatch(t, []sdk.AccAddress{addr2, addr3}, k.GetFollowing(ctx, addr1))
+	require.ElementsMatch(t, []sdk.AccAddress{addr1, addr3}, k.GetFollowers(ctx, addr2))
+
+	data.Followings = []Following{{addr1, addr1}}
+	ctx, _, k, setter = createTestInput(t)
+	require.Panics(t, func() { InitGenesis(ctx, k, setter, data) })
+}
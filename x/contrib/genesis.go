@@ -0,0 +1,145 @@
+package contrib
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+// GenesisState - params, bounties, delegations and follow graph of the
+// contrib module
+type GenesisState struct {
+	GasPriceDenom  string  `json:"gas_price_denom"`
+	MinGasPrice    sdk.Rat `json:"min_gas_price"`
+	DiscountRepute int64   `json:"discount_repute"`
+	DiscountRate   sdk.Rat `json:"discount_rate"`
+	FreeRepute     int64   `json:"free_repute"`
+	FreeDailyQuota int64   `json:"free_daily_quota"`
+
+	BountyReputeBonus       int64    `json:"bounty_repute_bonus"`
+	BountyArbitrationPeriod int64    `json:"bounty_arbitration_period"`
+	Bounties                []Bounty `json:"bounties"`
+
+	ReputeLedgerMaxEntries int64 `json:"repute_ledger_max_entries"`
+	ReputeLedgerMaxAge     int64 `json:"repute_ledger_max_age"`
+
+	Delegations []ReputeDelegation `json:"delegations"`
+	Followings  []Following        `json:"followings"`
+}
+
+// DefaultGenesisState - the defaults used when no params are stored
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		GasPriceDenom:  defaultGasPriceDenom,
+		MinGasPrice:    defaultMinGasPrice,
+		DiscountRepute: defaultDiscountRepute,
+		DiscountRate:   defaultDiscountRate,
+		FreeRepute:     defaultFreeRepute,
+		FreeDailyQuota: defaultFreeDailyQuota,
+
+		BountyReputeBonus:       defaultBountyReputeBonus,
+		BountyArbitrationPeriod: defaultBountyArbitrationPeriod,
+
+		ReputeLedgerMaxEntries: defaultReputeLedgerMaxEntries,
+		ReputeLedgerMaxAge:     defaultReputeLedgerMaxAge,
+	}
+}
+
+// InitGenesis stores the params, bounties, delegations and follow graph. Genesis files written before
+// the params existed leave the defaults in place. The repute ledgers open
+// with the repute of the genesis accounts.
+func InitGenesis(ctx sdk.Context, k Keeper, setter params.Setter, data GenesisState) {
+	if data.GasPriceDenom != "" && data.MinGasPrice.Rat != nil && data.DiscountRate.Rat != nil {
+		// zero ledger params keep every entry
+		setter.SetInt64(ctx, ReputeLedgerMaxEntriesKey, data.ReputeLedgerMaxEntries)
+		setter.SetInt64(ctx, ReputeLedgerMaxAgeKey, data.ReputeLedgerMaxAge)
+		setter.SetString(ctx, GasPriceDenomKey, data.GasPriceDenom)
+		setter.SetRat(ctx, MinGasPriceKey, data.MinGasPrice)
+		setter.SetInt64(ctx, DiscountReputeKey, data.DiscountRepute)
+		setter.SetRat(ctx, DiscountRateKey, data.DiscountRate)
+		setter.SetInt64(ctx, FreeReputeKey, data.FreeRepute)
+		setter.SetInt64(ctx, FreeDailyQuotaKey, data.FreeDailyQuota)
+	}
+	if data.BountyArbitrationPeriod > 0 {
+		setter.SetInt64(ctx, BountyReputeBonusKey, data.BountyReputeBonus)
+		setter.SetInt64(ctx, BountyArbitrationPeriodKey, data.BountyArbitrationPeriod)
+	}
+
+	store := ctx.KVStore(k.storeKey)
+	var nextID int64
+	for _, bounty := range data.Bounties {
+		k.setBounty(ctx, bounty)
+		switch bounty.Status {
+		case BountyOpen:
+			store.Set(getBountyQueueKey(bounty.Expiry, bounty.ID), []byte{})
+		case BountyExpired:
+			store.Set(getBountyQueueKey(bounty.Expiry.Add(k.BountyArbitrationPeriod(ctx)), bounty.ID), []byte{})
+		}
+		if bounty.ID >= nextID {
+			nextID = bounty.ID + 1
+		}
+	}
+	store.Set(bountyIDKey, k.cdc.MustMarshalBinary(nextID))
+
+	for _, delegation := range data.Delegations {
+		err := k.DelegateRepute(ctx, delegation.Delegator, delegation.Delegatee)
+		if err != nil {
+			panic(fmt.Sprintf("genesis delegation of %s: %s", delegation.Delegator, err.Error()))
+		}
+	}
+	for _, f := range data.Followings {
+		if len(f.Follower) != sdk.AddrLen || len(f.Followee) != sdk.AddrLen || bytes.Equal(f.Follower, f.Followee) {
+			panic(fmt.Sprintf("genesis following of %s: invalid followee %s", f.Follower, f.Followee))
+		}
+		k.setFollowing(ctx, f)
+	}
+
+	// the names are kept in the genesis accounts, each must be valid and held
+	// by a single account
+	k.indexNames(ctx, func(addr sdk.AccAddress, name, reason string) {
+		panic(fmt.Sprintf("genesis account %s: %s %s", addr, reason, name))
+	})
+	k.openReputeLedgers(ctx)
+	k.markMigrated(ctx)
+}
+
+// WriteGenesis returns the params in use, the bounties, the delegations and
+// the follow graph
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var bounties []Bounty
+	k.IterateBounties(ctx, func(bounty Bounty) bool {
+		bounties = append(bounties, bounty)
+		return false
+	})
+	var delegations []ReputeDelegation
+	k.IterateDelegations(ctx, func(delegation ReputeDelegation) bool {
+		delegations = append(delegations, delegation)
+		return false
+	})
+	var followings []Following
+	k.IterateFollowings(ctx, func(f Following) bool {
+		followings = append(followings, f)
+		return false
+	})
+
+	return GenesisState{
+		GasPriceDenom:  k.GasPriceDenom(ctx),
+		MinGasPrice:    k.MinGasPrice(ctx),
+		DiscountRepute: k.DiscountRepute(ctx),
+		DiscountRate:   k.DiscountRate(ctx),
+		FreeRepute:     k.FreeRepute(ctx),
+		FreeDailyQuota: k.FreeDailyQuota(ctx),
+
+		BountyReputeBonus:       k.BountyReputeBonus(ctx),
+		BountyArbitrationPeriod: int64(k.BountyArbitrationPeriod(ctx).Seconds()),
+		Bounties:                bounties,
+
+		ReputeLedgerMaxEntries: k.ReputeLedgerMaxEntries(ctx),
+		ReputeLedgerMaxAge:     k.ReputeLedgerMaxAge(ctx),
+
+		Delegations: delegations,
+		Followings:  followings,
+	}
+}
@@ -1,6 +1,7 @@
 package contrib
 
 import (
+	"fmt"
 	"reflect"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
@@ -12,6 +13,18 @@ func NewHandler(k Keeper) sdk.Handler {
 		switch msg := msg.(type) {
 		case MsgContrib:
 			return handleMsgContrib(ctx, k, msg)
+		case MsgDelegateRepute:
+			return handleMsgDelegateRepute(ctx, k, msg)
+		case MsgUndelegateRepute:
+			return handleMsgUndelegateRepute(ctx, k, msg)
+		case MsgCreateBounty:
+			return handleMsgCreateBounty(ctx, k, msg)
+		case MsgSubmitBounty:
+			return handleMsgSubmitBounty(ctx, k, msg)
+		case MsgAwardBounty:
+			return handleMsgAwardBounty(ctx, k, msg)
+		case MsgSetProfile:
+			return handleMsgSetProfile(ctx, k, msg)
 		default:
 			errMsg := "Unrecognized contrib Msg type: " + reflect.TypeOf(msg).Name()
 			return sdk.ErrUnknownRequest(errMsg).Result()
@@ -22,15 +35,113 @@ func NewHandler(k Keeper) sdk.Handler {
 // Handle MsgContrib.
 func handleMsgContrib(ctx sdk.Context, k Keeper, msg MsgContrib) sdk.Result {
 	tags := sdk.EmptyTags()
+	results := make([]ContribResult, 0, len(msg.Contribs))
 
 	for _, ctb := range msg.Contribs {
-		err := k.UpdateContrib(ctx, ctb, &tags)
+		res, err := k.UpdateContrib(ctx, ctb, &tags)
 		if err != nil {
 			return err.Result()
 		}
+		results = append(results, res)
 	}
 
 	return sdk.Result{
+		Data: k.cdc.MustMarshalBinary(results),
 		Tags: tags,
 	}
 }
+
+// Handle MsgDelegateRepute.
+func handleMsgDelegateRepute(ctx sdk.Context, k Keeper, msg MsgDelegateRepute) sdk.Result {
+	err := k.DelegateRepute(ctx, msg.Delegator, msg.Delegatee)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"delegator", msg.Delegator.Bytes(),
+			"delegatee", msg.Delegatee.Bytes(),
+		),
+	}
+}
+
+// Handle MsgUndelegateRepute.
+func handleMsgUndelegateRepute(ctx sdk.Context, k Keeper, msg MsgUndelegateRepute) sdk.Result {
+	err := k.UndelegateRepute(ctx, msg.Delegator)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"delegator", msg.Delegator.Bytes(),
+		),
+	}
+}
+
+// Handle MsgCreateBounty.
+func handleMsgCreateBounty(ctx sdk.Context, k Keeper, msg MsgCreateBounty) sdk.Result {
+	bounty, err := k.CreateBounty(ctx, msg.Creator, msg.Description, msg.Reward, msg.Expiry)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Data: k.cdc.MustMarshalBinary(bounty.ID),
+		Tags: sdk.NewTags(
+			"bounty", []byte(fmt.Sprint(bounty.ID)),
+			"creator", msg.Creator.Bytes(),
+		),
+	}
+}
+
+// Handle MsgSubmitBounty.
+func handleMsgSubmitBounty(ctx sdk.Context, k Keeper, msg MsgSubmitBounty) sdk.Result {
+	err := k.SubmitBounty(ctx, msg.Contributor, msg.BountyID, msg.PostKey)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"bounty", []byte(fmt.Sprint(msg.BountyID)),
+			"contributor", msg.Contributor.Bytes(),
+		),
+	}
+}
+
+// Handle MsgAwardBounty.
+func handleMsgAwardBounty(ctx sdk.Context, k Keeper, msg MsgAwardBounty) sdk.Result {
+	sub, err := k.AwardBounty(ctx, msg.Awarder, msg.BountyID, msg.PostKey)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"bounty", []byte(fmt.Sprint(msg.BountyID)),
+			"winner", sub.Contributor.Bytes(),
+		),
+	}
+}
+
+// Handle MsgSetProfile.
+func handleMsgSetProfile(ctx sdk.Context, k Keeper, msg MsgSetProfile) sdk.Result {
+	err := k.SetProfile(ctx, msg.Owner, msg.Name, msg.Bio, msg.Avatar)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"owner", msg.Owner.Bytes(),
+			"name", []byte(msg.Name),
+		),
+	}
+}
+
+// EndBlocker refunds or closes the bounties past their deadline
+func EndBlocker(ctx sdk.Context, k Keeper) sdk.Tags {
+	return k.ProcessExpiredBounties(ctx)
+}
@@ -0,0 +1,61 @@
+package contrib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestHandleMsgContrib(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, nil)
+	setAccount(ctx, am, addr2, 0, nil)
+	handler := NewHandler(k)
+
+	post := newPost("post", addr1, addr2, genesisTime)
+	vote := newVote("vote", addr2, addr1, genesisTime, 1)
+	res := handler(ctx, NewMsgContrib([]Contrib{post, vote}))
+	require.True(t, res.IsOK(), res.Log)
+
+	var results []ContribResult
+	require.Nil(t, k.cdc.UnmarshalBinary(res.Data, &results))
+	require.Len(t, results, 2)
+	for i, ctb := range []Contrib{post, vote} {
+		status, err := getStatus(ctx.KVStore(k.storeKey), ctb.GetKey(), k.cdc)
+		require.Nil(t, err)
+		require.Equal(t, ContribResult{
+			Key:        ctb.GetKey(),
+			Type:       ContribType(ctb),
+			Status:     status,
+			ScoreDelta: 1,
+			Repute:     getRepute(ctx, am, ctb.GetContributor()),
+		}, results[i])
+	}
+	require.Equal(t, "Post", results[0].Type)
+	require.Equal(t, "Vote", results[1].Type)
+
+	expected := sdk.NewTags(
+		"contributor", addr1.Bytes(),
+		"recipient", addr2.Bytes(),
+		"contrib-type", []byte("Post"),
+		"contrib-key", []byte(results[0].Key.String()),
+		"score-delta", []byte("1"),
+		"repute", []byte("1"),
+		"contributor", addr2.Bytes(),
+		"recipient", addr1.Bytes(),
+		"contrib-type", []byte("Vote"),
+		"contrib-key", []byte(results[1].Key.String()),
+		"score-delta", []byte("1"),
+		"repute", []byte("1"),
+	)
+	require.Equal(t, expected, res.Tags)
+
+	// a failing contrib fails the message
+	later := genesisTime.Add(time.Minute)
+	res = handler(ctx, NewMsgContrib([]Contrib{newPost("post2", addr1, addr2, later), newVote("post", addr2, addr1, later, 1)}))
+	require.Equal(t, sdk.ToABCICode(DefaultCodespace, CodeInvalidContrib), res.Code)
+	require.Nil(t, res.Data)
+}
@@ -2,57 +2,213 @@ package contrib
 
 import (
 	"fmt"
+	"reflect"
+	"strconv"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/wire"
 	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
 	"github.com/forbole/forboled/types"
 )
 
+// Gas charged for the bytes a contrib adds to the chain
+const (
+	GasPerContribByte sdk.Gas = 10
+	GasPerStatusByte  sdk.Gas = 10
+)
+
 // Keeper manages transfers between accounts
 type Keeper struct {
 	cdc      *wire.Codec
 	am       auth.AccountMapper
+	ck       bank.Keeper
 	storeKey sdk.StoreKey
+	params   params.Getter
 }
 
 // NewKeeper returns a new Keeper
-func NewKeeper(cdc *wire.Codec, am auth.AccountMapper, storeKey sdk.StoreKey) Keeper {
-	return Keeper{cdc: cdc, am: am, storeKey: storeKey}
+func NewKeeper(cdc *wire.Codec, am auth.AccountMapper, ck bank.Keeper, storeKey sdk.StoreKey, params params.Getter) Keeper {
+	return Keeper{cdc: cdc, am: am, ck: ck, storeKey: storeKey, params: params}
+}
+
+// tipper is implemented by the contribs able to carry a tip
+type tipper interface {
+	GetTip() sdk.Coins
+	GetRecipient() sdk.AccAddress
+}
+
+// tipStatus is implemented by the statuses keeping a tip total
+type tipStatus interface {
+	AddTip(sdk.Coins)
+}
+
+// Keys starting with ReservedKeyPrefix hold module data next to the
+// statuses, contribs cannot use them. The statuses stored under it before it
+// was reserved are moved by a migration.
+const ReservedKeyPrefix = byte(0x00)
+
+var freeQuotaKeyPrefix = []byte{ReservedKeyPrefix, 0x01}
+
+// GetFreeQuotaKey returns the store key of the free quota of an account
+func GetFreeQuotaKey(addr sdk.AccAddress) []byte {
+	return append(freeQuotaKeyPrefix, addr.Bytes()...)
+}
+
+// FreeQuota counts the free contrib txs of an account on a day
+type FreeQuota struct {
+	Day  int64 `json:"day"`
+	Used int64 `json:"used"`
 }
 
-func (k Keeper) UpdateContrib(ctx sdk.Context, ctb Contrib, tags *sdk.Tags) sdk.Error {
+func (k Keeper) getFreeQuota(ctx sdk.Context, addr sdk.AccAddress) (quota FreeQuota) {
+	bz := ctx.KVStore(k.storeKey).Get(GetFreeQuotaKey(addr))
+	if bz != nil {
+		k.cdc.MustUnmarshalBinary(bz, &quota)
+	}
+	return quota
+}
+
+func (k Keeper) setFreeQuota(ctx sdk.Context, addr sdk.AccAddress, quota FreeQuota) {
+	ctx.KVStore(k.storeKey).Set(GetFreeQuotaKey(addr), k.cdc.MustMarshalBinary(quota))
+}
+
+// ContribResult is the outcome of a contrib, MsgContrib returns them in
+// Result.Data
+type ContribResult struct {
+	Key        cmn.HexBytes `json:"key"`
+	Type       string       `json:"type"`
+	Status     Status       `json:"status"`
+	ScoreDelta int64        `json:"score_delta"`
+	Repute     int64        `json:"repute"`
+}
+
+// ContribType returns the type name of a contrib, e.g. "Post"
+func ContribType(ctb Contrib) string {
+	return reflect.Indirect(reflect.ValueOf(ctb)).Type().Name()
+}
+
+func (k Keeper) UpdateContrib(ctx sdk.Context, ctb Contrib, tags *sdk.Tags) (res ContribResult, err sdk.Error) {
 	acc, err := ctb.ValidateAccounts(ctx, k.am)
 	if err != nil {
-		return err
+		return res, err
 	}
 
 	ctb.AppendTags(tags)
 
+	// charge for the contrib itself so large contents cost more
+	ctx.GasMeter().ConsumeGas(GasPerContribByte*sdk.Gas(len(k.cdc.MustMarshalBinaryBare(ctb))), "contrib")
+
 	var oldscore int64
 	store := ctx.KVStore(k.storeKey)
 	key := ctb.GetKey()
 
 	status, err := getStatus(store, key, k.cdc)
 	if err != nil {
-		return err
+		return res, err
+	}
+	isNew := status == nil
+	var oldVote VoteStatus
+	if vote, ok := status.(*VoteStatus); ok {
+		oldVote = *vote
 	}
-	if status != nil {
+	if !isNew {
+		// a key belongs to the contrib type that used it first
+		if reflect.TypeOf(status) != reflect.TypeOf(ctb.NewStatus()) {
+			return res, ErrInvalidContrib(DefaultCodespace, "key used by another contrib type")
+		}
 		oldscore = status.GetScore()
 		err := status.Update(ctb)
 		if err != nil {
-			return err
+			return res, err
 		}
 	} else {
 		oldscore = 0
 		status = ctb.NewStatus()
 	}
 	diff := status.GetScore() - oldscore
-	setStatus(store, key, status, k.cdc)
-	updateRepute(acc, diff)
+
+	var tip sdk.Coins
+	var recipient sdk.AccAddress
+	if t, ok := ctb.(tipper); ok {
+		tip, recipient = t.GetTip(), t.GetRecipient()
+	}
+	if len(tip) > 0 {
+		ts, ok := status.(tipStatus)
+		if !ok {
+			return res, ErrInvalidContrib(DefaultCodespace, "status cannot keep tips")
+		}
+		ts.AddTip(tip)
+	}
+
+	if vote, ok := status.(*VoteStatus); ok {
+		k.tallyVote(ctx, key, oldVote, vote)
+	}
+
+	written := setStatus(store, key, status, k.cdc)
+	ctx.GasMeter().ConsumeGas(GasPerStatusByte*sdk.Gas(len(key)+written), "contrib status")
+	if h, ok := ctb.(updateHook); ok {
+		h.afterUpdate(ctx, k, isNew)
+	}
+	entry := ReputeEntry{Key: key, Type: ContribType(ctb), Delta: diff}
+	if c, ok := ctb.(interface{ GetRecipient() sdk.AccAddress }); ok {
+		entry.Counterparty = c.GetRecipient()
+	}
+	k.updateRepute(ctx, acc, entry)
 	k.am.SetAccount(ctx, acc)
 
-	return nil
+	res = ContribResult{
+		Key:        key,
+		Type:       ContribType(ctb),
+		Status:     status,
+		ScoreDelta: diff,
+		Repute:     acc.(*types.ReputeAccount).Repute,
+	}
+	*tags = tags.AppendTag("contrib-type", []byte(res.Type)).
+		AppendTag("contrib-key", []byte(res.Key.String())).
+		AppendTag("score-delta", []byte(strconv.FormatInt(diff, 10))).
+		AppendTag("repute", []byte(strconv.FormatInt(res.Repute, 10)))
+
+	// acc is saved first, sending the tip updates the stored account
+	if len(tip) > 0 {
+		_, err := k.ck.SendCoins(ctx, ctb.GetContributor(), recipient, tip)
+		if err != nil {
+			return res, err
+		}
+		*tags = tags.AppendTag("tipper", ctb.GetContributor().Bytes()).
+			AppendTag("tipped", recipient.Bytes()).
+			AppendTag("tip", []byte(tip.String()))
+	}
+
+	return res, nil
+}
+
+// IterateStatuses iterates over the contrib statuses in key order, starting
+// with the ones moved from the reserved key prefix
+func (k Keeper) IterateStatuses(ctx sdk.Context, process func(key []byte, status Status) (stop bool)) {
+	store := ctx.KVStore(k.storeKey)
+	reserved := sdk.KVStorePrefixIterator(store, reservedStatusPrefix)
+	defer reserved.Close()
+	for ; reserved.Valid(); reserved.Next() {
+		var status Status
+		k.cdc.MustUnmarshalBinaryBare(reserved.Value(), &status)
+		if process(reserved.Key()[len(reservedStatusPrefix):], status) {
+			return
+		}
+	}
+
+	iter := store.Iterator([]byte{ReservedKeyPrefix + 1}, nil)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var status Status
+		k.cdc.MustUnmarshalBinaryBare(iter.Value(), &status)
+		if process(iter.Key(), status) {
+			return
+		}
+	}
 }
 
 func getStatus(store sdk.KVStore, key []byte, cdc *wire.Codec) (Status, sdk.Error) {
@@ -69,11 +225,9 @@ func getStatus(store sdk.KVStore, key []byte, cdc *wire.Codec) (Status, sdk.Erro
 	return nil, nil
 }
 
-func setStatus(store sdk.KVStore, key []byte, status Status, cdc *wire.Codec) {
+// setStatus writes the status and returns the number of bytes written
+func setStatus(store sdk.KVStore, key []byte, status Status, cdc *wire.Codec) int {
 	bin, _ := cdc.MarshalBinaryBare(status)
 	store.Set(key, bin)
-}
-
-func updateRepute(acc auth.Account, diff int64) {
-	acc.(*types.ReputeAccount).Repute += diff
+	return len(bin)
 }
@@ -0,0 +1,190 @@
+package contrib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/forbole/forboled/types"
+)
+
+var (
+	addr1 = sdk.AccAddress(tmhash.Sum([]byte("addr1")))
+	addr2 = sdk.AccAddress(tmhash.Sum([]byte("addr2")))
+
+	genesisTime = time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func makeTestCodec() *wire.Codec {
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*auth.Account)(nil), nil)
+	cdc.RegisterConcrete(&auth.BaseAccount{}, "auth/Account", nil)
+	cdc.RegisterConcrete(&types.ReputeAccount{}, "forbole/Repute", nil)
+	return cdc
+}
+
+// createTestInput returns a context at height 1 over fresh acc, contrib and
+// params stores, with a params setter to change the contrib params
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountMapper, Keeper, params.Setter) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keyContrib := sdk.NewKVStoreKey("contrib")
+	keyParams := sdk.NewKVStoreKey("params")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyContrib, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keyParams, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1, Time: genesisTime}, false, log.NewNopLogger())
+
+	cdc := makeTestCodec()
+	am := auth.NewAccountMapper(cdc, keyAcc, types.ProtoReputeAccount)
+	pk := params.NewKeeper(cdc, keyParams)
+	k := NewKeeper(cdc, am, bank.NewKeeper(am), keyContrib, pk.Getter())
+	return ctx, am, k, pk.Setter()
+}
+
+// setAccount stores a repute account holding coins
+func setAccount(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, repute int64, coins sdk.Coins) *types.ReputeAccount {
+	acc := am.NewAccountWithAddress(ctx, addr).(*types.ReputeAccount)
+	acc.Repute = repute
+	acc.Coins = coins
+	am.SetAccount(ctx, acc)
+	return acc
+}
+
+func getRepute(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress) int64 {
+	return am.GetAccount(ctx, addr).(*types.ReputeAccount).Repute
+}
+
+func newPost(key string, contributor, recipient sdk.AccAddress, t time.Time) *Post {
+	return &Post{BaseContrib2: BaseContrib2{BaseContrib{[]byte(key), contributor, t}, recipient}}
+}
+
+func newVote(key string, contributor, recipient sdk.AccAddress, t time.Time, vote int64) *Vote {
+	return &Vote{BaseContrib3: BaseContrib3{BaseContrib{[]byte(key), contributor, t}, recipient, vote}}
+}
+
+func TestUpdateContribKeyOfAnotherType(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, nil)
+	setAccount(ctx, am, addr2, 0, nil)
+	later := genesisTime.Add(time.Minute)
+
+	cases := []struct {
+		name  string
+		first Contrib
+		reuse Contrib
+	}{
+		{"vote on a post key", newPost("post", addr1, addr2, genesisTime), newVote("post", addr1, addr2, later, 1)},
+		{"post on a vote key", newVote("vote", addr1, addr2, genesisTime, 1), newPost("vote", addr1, addr2, later)},
+	}
+	for _, tc := range cases {
+		tags := sdk.EmptyTags()
+		_, err := k.UpdateContrib(ctx, tc.first, &tags)
+		require.Nil(t, err, tc.name)
+		repute := getRepute(ctx, am, addr1)
+
+		// the second contrib must neither panic nor score the first status
+		_, err = k.UpdateContrib(ctx, tc.reuse, &tags)
+		require.NotNil(t, err, tc.name)
+		require.Equal(t, CodeInvalidContrib, err.Code(), tc.name)
+		require.Equal(t, repute, getRepute(ctx, am, addr1), tc.name)
+
+		status, _ := getStatus(ctx.KVStore(k.storeKey), tc.first.GetKey(), k.cdc)
+		require.Equal(t, int64(1), status.GetScore(), tc.name)
+	}
+}
+
+func TestUpdateContribTip(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, sdk.Coins{sdk.NewInt64Coin("fbc", 100)})
+	setAccount(ctx, am, addr2, 0, nil)
+
+	post := newPost("post", addr1, addr2, genesisTime)
+	post.Tip = sdk.Coins{sdk.NewInt64Coin("fbc", 10)}
+	recommend := &Recommend{BaseContrib2: BaseContrib2{BaseContrib{[]byte("recommend"), addr1, genesisTime}, addr2}}
+	recommend.Tip = sdk.Coins{sdk.NewInt64Coin("fbc", 20)}
+	again := newPost("post", addr1, addr2, genesisTime.Add(time.Minute))
+	again.Tip = sdk.Coins{sdk.NewInt64Coin("fbc", 5)}
+	tooLarge := newPost("large", addr1, addr2, genesisTime)
+	tooLarge.Tip = sdk.Coins{sdk.NewInt64Coin("fbc", 100)}
+
+	cases := []struct {
+		name     string
+		ctb      Contrib
+		code     sdk.CodeType
+		total    sdk.Coins
+		balance1 int64
+		balance2 int64
+	}{
+		{"post tip", post, sdk.CodeOK, sdk.Coins{sdk.NewInt64Coin("fbc", 10)}, 90, 10},
+		{"recommend tip", recommend, sdk.CodeOK, sdk.Coins{sdk.NewInt64Coin("fbc", 20)}, 70, 30},
+		{"tips add up", again, sdk.CodeOK, sdk.Coins{sdk.NewInt64Coin("fbc", 15)}, 65, 35},
+		{"insufficient funds", tooLarge, sdk.CodeInsufficientCoins, nil, 65, 35},
+	}
+	for _, tc := range cases {
+		tags := sdk.EmptyTags()
+		_, err := k.UpdateContrib(ctx, tc.ctb, &tags)
+		if tc.code != sdk.CodeOK {
+			require.NotNil(t, err, tc.name)
+			require.Equal(t, tc.code, err.Code(), tc.name)
+		} else {
+			require.Nil(t, err, tc.name)
+			require.Equal(t, tc.total, getTipTotal(ctx, k, tc.ctb.GetKey()), tc.name)
+		}
+		require.Equal(t, tc.balance1, am.GetAccount(ctx, addr1).GetCoins().AmountOf("fbc").Int64(), tc.name)
+		require.Equal(t, tc.balance2, am.GetAccount(ctx, addr2).GetCoins().AmountOf("fbc").Int64(), tc.name)
+	}
+}
+
+func getTipTotal(ctx sdk.Context, k Keeper, key []byte) sdk.Coins {
+	status, _ := getStatus(ctx.KVStore(k.storeKey), key, k.cdc)
+	switch status := status.(type) {
+	case *PostStatus:
+		return status.TipTotal
+	case *RecommendStatus:
+		return status.TipTotal
+	}
+	return nil
+}
+
+func TestUpdateContribGas(t *testing.T) {
+	gasUsed := func(size int) sdk.Gas {
+		ctx, am, k, _ := createTestInput(t)
+		setAccount(ctx, am, addr1, 0, nil)
+		setAccount(ctx, am, addr2, 0, nil)
+		ctx = ctx.WithGasMeter(sdk.NewGasMeter(1000000))
+
+		post := newPost("post", addr1, addr2, genesisTime)
+		post.Content = make([]byte, size)
+		tags := sdk.EmptyTags()
+		res, err := k.UpdateContrib(ctx, post, &tags)
+		require.Nil(t, err)
+
+		// the contrib and the status it wrote are charged on top of the store gas
+		used := ctx.GasMeter().GasConsumed()
+		charged := GasPerContribByte*sdk.Gas(len(k.cdc.MustMarshalBinaryBare(post))) +
+			GasPerStatusByte*sdk.Gas(len(post.Key)+len(k.cdc.MustMarshalBinaryBare(res.Status)))
+		require.True(t, used > charged, "used %d gas, charged %d for the bytes", used, charged)
+		return used
+	}
+
+	// the status of a post does not hold its content
+	require.Equal(t, 100*GasPerContribByte, gasUsed(300)-gasUsed(200))
+}
@@ -0,0 +1,179 @@
+package contrib
+
+import (
+	"encoding/binary"
+	"sort"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	cmn "github.com/tendermint/tendermint/libs/common"
+
+	"github.com/forbole/forboled/types"
+)
+
+var (
+	reputeLedgerKeyPrefix       = []byte{ReservedKeyPrefix, 0x0B} // account, seq -> entry
+	reputeLedgerBoundsKeyPrefix = []byte{ReservedKeyPrefix, 0x0C} // account -> bounds
+)
+
+// Entry types besides the contrib types
+const (
+	BountyEntryType  = "Bounty"  // repute bonus of a bounty
+	OpeningEntryType = "Opening" // repute held before the ledger, e.g. at genesis
+)
+
+// GetReputeLedgerKey returns the prefix of the repute ledger of an account
+func GetReputeLedgerKey(addr sdk.AccAddress) []byte {
+	return append(reputeLedgerKeyPrefix, addr.Bytes()...)
+}
+
+func getReputeLedgerEntryKey(addr sdk.AccAddress, seq int64) []byte {
+	bz := make([]byte, 8)
+	binary.BigEndian.PutUint64(bz, uint64(seq))
+	return append(GetReputeLedgerKey(addr), bz...)
+}
+
+func getReputeLedgerBoundsKey(addr sdk.AccAddress) []byte {
+	return append(reputeLedgerBoundsKeyPrefix, addr.Bytes()...)
+}
+
+// ReputeEntry explains a change of the repute of an account
+type ReputeEntry struct {
+	Height       int64          `json:"height"`
+	Key          cmn.HexBytes   `json:"key"`  // contrib key, or post key of a bounty
+	Type         string         `json:"type"` // contrib type, or Bounty
+	Counterparty sdk.AccAddress `json:"counterparty"`
+	Delta        int64          `json:"delta"`
+}
+
+// reputeLedgerBounds are the sequences of the oldest entry kept and of the
+// next entry of an account
+type reputeLedgerBounds struct {
+	First int64 `json:"first"`
+	Next  int64 `json:"next"`
+}
+
+// updateRepute applies the delta of entry to acc and appends entry to the
+// ledger of acc. The caller saves acc.
+func (k Keeper) updateRepute(ctx sdk.Context, acc auth.Account, entry ReputeEntry) {
+	if entry.Delta == 0 {
+		return
+	}
+	acc.(*types.ReputeAccount).Repute += entry.Delta
+	k.appendReputeEntry(ctx, acc.GetAddress(), entry)
+}
+
+// appendReputeEntry appends entry to the ledger of addr at the current
+// height
+func (k Keeper) appendReputeEntry(ctx sdk.Context, addr sdk.AccAddress, entry ReputeEntry) {
+	entry.Height = ctx.BlockHeight()
+	store := ctx.KVStore(k.storeKey)
+
+	var bounds reputeLedgerBounds
+	if bz := store.Get(getReputeLedgerBoundsKey(addr)); bz != nil {
+		k.cdc.MustUnmarshalBinary(bz, &bounds)
+	}
+	store.Set(getReputeLedgerEntryKey(addr, bounds.Next), k.cdc.MustMarshalBinary(entry))
+	bounds.Next++
+
+	k.pruneReputeLedger(ctx, addr, &bounds)
+	store.Set(getReputeLedgerBoundsKey(addr), k.cdc.MustMarshalBinary(bounds))
+}
+
+// openReputeLedgers appends an opening entry to the ledgers that do not sum
+// up to the repute of their account, for the repute held before the ledger.
+// The ledgers already pruned cannot be balanced and are left as is.
+func (k Keeper) openReputeLedgers(ctx sdk.Context) {
+	var openings []ReputeEntry
+	var addrs []sdk.AccAddress
+	k.am.IterateAccounts(ctx, func(acc auth.Account) bool {
+		racc, ok := acc.(*types.ReputeAccount)
+		if !ok || !k.IsReputeLedgerComplete(ctx, racc.Address) {
+			return false
+		}
+		delta := racc.Repute
+		for _, entry := range k.GetReputeLedger(ctx, racc.Address) {
+			delta -= entry.Delta
+		}
+		if delta != 0 {
+			openings = append(openings, ReputeEntry{Type: OpeningEntryType, Delta: delta})
+			addrs = append(addrs, racc.Address)
+		}
+		return false
+	})
+	for i, entry := range openings {
+		k.appendReputeEntry(ctx, addrs[i], entry)
+	}
+}
+
+// pruneReputeLedger drops the oldest entries of addr beyond
+// ReputeLedgerMaxEntries or older than ReputeLedgerMaxAge. Ledgers are only
+// pruned when they grow, so the ledger of an idle account keeps old entries.
+func (k Keeper) pruneReputeLedger(ctx sdk.Context, addr sdk.AccAddress, bounds *reputeLedgerBounds) {
+	store := ctx.KVStore(k.storeKey)
+	maxEntries := k.ReputeLedgerMaxEntries(ctx)
+	maxAge := k.ReputeLedgerMaxAge(ctx)
+
+	for ; bounds.First < bounds.Next; bounds.First++ {
+		key := getReputeLedgerEntryKey(addr, bounds.First)
+		if maxEntries == 0 || bounds.Next-bounds.First <= maxEntries {
+			if maxAge == 0 {
+				return
+			}
+			var entry ReputeEntry
+			k.cdc.MustUnmarshalBinary(store.Get(key), &entry)
+			if entry.Height > ctx.BlockHeight()-maxAge {
+				return
+			}
+		}
+		store.Delete(key)
+	}
+}
+
+// GetReputeLedger returns the kept repute ledger of addr, oldest first
+func (k Keeper) GetReputeLedger(ctx sdk.Context, addr sdk.AccAddress) (entries []ReputeEntry) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), GetReputeLedgerKey(addr))
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var entry ReputeEntry
+		k.cdc.MustUnmarshalBinary(iter.Value(), &entry)
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// IsReputeLedgerComplete tells whether no entry of addr was pruned yet
+func (k Keeper) IsReputeLedgerComplete(ctx sdk.Context, addr sdk.AccAddress) bool {
+	var bounds reputeLedgerBounds
+	if bz := ctx.KVStore(k.storeKey).Get(getReputeLedgerBoundsKey(addr)); bz != nil {
+		k.cdc.MustUnmarshalBinary(bz, &bounds)
+	}
+	return bounds.First == 0
+}
+
+// ReputeTypeTotal sums the repute changes of a contrib type
+type ReputeTypeTotal struct {
+	Type  string `json:"type"`
+	Count int64  `json:"count"`
+	Delta int64  `json:"delta"`
+}
+
+// ReputeBreakdown sums the entries by type, sorted by type
+func ReputeBreakdown(entries []ReputeEntry) []ReputeTypeTotal {
+	totals := make(map[string]*ReputeTypeTotal)
+	breakdown := []ReputeTypeTotal{}
+	for _, entry := range entries {
+		total, ok := totals[entry.Type]
+		if !ok {
+			total = &ReputeTypeTotal{Type: entry.Type}
+			totals[entry.Type] = total
+		}
+		total.Count++
+		total.Delta += entry.Delta
+	}
+	for _, total := range totals {
+		breakdown = append(breakdown, *total)
+	}
+	sort.Slice(breakdown, func(i, j int) bool { return breakdown[i].Type < breakdown[j].Type })
+	return breakdown
+}
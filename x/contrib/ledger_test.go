@@ -0,0 +1,108 @@
+package contrib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// addEntries adds n repute entries of delta 1 to addr, one per block from
+// height
+func addEntries(ctx sdk.Context, k Keeper, addr sdk.AccAddress, height int64, n int) sdk.Context {
+	for i := 0; i < n; i++ {
+		ctx = ctx.WithBlockHeight(height + int64(i))
+		acc := k.am.GetAccount(ctx, addr)
+		k.updateRepute(ctx, acc, ReputeEntry{Type: "Post", Delta: 1})
+		k.am.SetAccount(ctx, acc)
+	}
+	return ctx
+}
+
+func entryHeights(entries []ReputeEntry) (heights []int64) {
+	for _, entry := range entries {
+		heights = append(heights, entry.Height)
+	}
+	return heights
+}
+
+func TestPruneReputeLedger(t *testing.T) {
+	cases := []struct {
+		name       string
+		maxEntries int64
+		maxAge     int64
+		heights    []int64
+	}{
+		{"keep all", 0, 0, []int64{1, 2, 3, 4, 5, 6}},
+		{"by count", 4, 0, []int64{3, 4, 5, 6}},
+		{"by age", 0, 3, []int64{4, 5, 6}},
+		{"by count and age", 2, 3, []int64{5, 6}},
+	}
+	for _, tc := range cases {
+		ctx, am, k, setter := createTestInput(t)
+		setter.SetInt64(ctx, ReputeLedgerMaxEntriesKey, tc.maxEntries)
+		setter.SetInt64(ctx, ReputeLedgerMaxAgeKey, tc.maxAge)
+		setAccount(ctx, am, addr1, 0, nil)
+
+		ctx = addEntries(ctx, k, addr1, 1, 6)
+		require.Equal(t, tc.heights, entryHeights(k.GetReputeLedger(ctx, addr1)), tc.name)
+		require.Equal(t, len(tc.heights) == 6, k.IsReputeLedgerComplete(ctx, addr1), tc.name)
+		require.Equal(t, int64(6), getRepute(ctx, am, addr1), tc.name)
+	}
+}
+
+// an idle ledger is only pruned by age when it grows again
+func TestPruneReputeLedgerIdle(t *testing.T) {
+	ctx, am, k, setter := createTestInput(t)
+	setter.SetInt64(ctx, ReputeLedgerMaxAgeKey, 10)
+	setAccount(ctx, am, addr1, 0, nil)
+
+	ctx = addEntries(ctx, k, addr1, 1, 3)
+	ctx = ctx.WithBlockHeight(100)
+	require.Len(t, k.GetReputeLedger(ctx, addr1), 3)
+
+	addEntries(ctx, k, addr1, 100, 1)
+	require.Equal(t, []int64{100}, entryHeights(k.GetReputeLedger(ctx, addr1)))
+}
+
+func TestInitGenesisReputeLedger(t *testing.T) {
+	ctx, am, k, setter := createTestInput(t)
+	ctx = ctx.WithBlockHeight(0)
+	setAccount(ctx, am, addr1, 7, nil)
+	setAccount(ctx, am, addr2, 0, nil)
+	setAccount(ctx, am, addr3, -2, nil)
+
+	// zero ledger params keep every entry rather than use the defaults
+	data := DefaultGenesisState()
+	data.ReputeLedgerMaxEntries = 0
+	data.ReputeLedgerMaxAge = 0
+	InitGenesis(ctx, k, setter, data)
+	require.Equal(t, int64(0), k.ReputeLedgerMaxEntries(ctx))
+	require.Equal(t, int64(0), k.ReputeLedgerMaxAge(ctx))
+
+	require.Equal(t, []ReputeEntry{{Type: OpeningEntryType, Delta: 7}}, k.GetReputeLedger(ctx, addr1))
+	require.Empty(t, k.GetReputeLedger(ctx, addr2))
+	require.Equal(t, []ReputeEntry{{Type: OpeningEntryType, Delta: -2}}, k.GetReputeLedger(ctx, addr3))
+	require.Equal(t, int64(7), getRepute(ctx, am, addr1))
+}
+
+// the ledgers of a chain started before them open with the repute missing
+// from their entries
+func TestMigrateReputeLedgers(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 5, nil)
+	setAccount(ctx, am, addr2, 0, nil)
+	ctx = addEntries(ctx, k, addr2, 1, 2)
+
+	ctx = ctx.WithBlockHeight(10)
+	BeginBlocker(ctx, k)
+	require.Equal(t, []ReputeEntry{{Height: 10, Type: OpeningEntryType, Delta: 5}}, k.GetReputeLedger(ctx, addr1))
+	require.Len(t, k.GetReputeLedger(ctx, addr2), 2)
+
+	// the migration only runs once
+	acc := setAccount(ctx, am, addr1, 8, nil)
+	require.Equal(t, int64(8), acc.Repute)
+	BeginBlocker(ctx.WithBlockHeight(11), k)
+	require.Len(t, k.GetReputeLedger(ctx, addr1), 1)
+}
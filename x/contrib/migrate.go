@@ -0,0 +1,92 @@
+package contrib
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+var (
+	migrationKeyPrefix   = []byte{ReservedKeyPrefix, 0x0E} // migration name -> nil
+	reservedStatusPrefix = []byte{ReservedKeyPrefix, 0x0F} // key -> status
+)
+
+// migration fills the contrib store of a chain started before the state it
+// introduced
+type migration struct {
+	name string
+	run  func(k Keeper, ctx sdk.Context)
+}
+
+// migrations run once, in order, on the chains that have not run them yet.
+// The reserved statuses move first, before the other migrations write under
+// the reserved prefix.
+var migrations = []migration{
+	{"reserved-statuses", Keeper.moveReservedStatuses},
+	{"names", Keeper.migrateNames},
+	{"repute-ledgers", Keeper.openReputeLedgers},
+}
+
+func getMigrationKey(name string) []byte {
+	return append(migrationKeyPrefix, []byte(name)...)
+}
+
+// BeginBlocker runs the migrations of the contrib store in the first block
+// after an upgrade, it is a no-op afterwards
+func BeginBlocker(ctx sdk.Context, k Keeper) {
+	store := ctx.KVStore(k.storeKey)
+	for _, m := range migrations {
+		if store.Has(getMigrationKey(m.name)) {
+			continue
+		}
+		m.run(k, ctx)
+		store.Set(getMigrationKey(m.name), []byte{})
+		ctx.Logger().Info("Migrated contrib store", "migration", m.name)
+	}
+}
+
+// markMigrated records the migrations as done, the genesis of a new chain
+// already holds their state
+func (k Keeper) markMigrated(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	for _, m := range migrations {
+		store.Set(getMigrationKey(m.name), []byte{})
+	}
+}
+
+func getReservedStatusKey(key []byte) []byte {
+	return append(reservedStatusPrefix, key...)
+}
+
+// moveReservedStatuses moves the statuses stored under ReservedKeyPrefix
+// before it was reserved out of the way of the module data. They keep their
+// key in IterateStatuses, but no contrib can update them anymore. The module
+// data does not decode as a status.
+func (k Keeper) moveReservedStatuses(ctx sdk.Context) {
+	store := ctx.KVStore(k.storeKey)
+	var keys [][]byte
+	iter := sdk.KVStorePrefixIterator(store, []byte{ReservedKeyPrefix})
+	for ; iter.Valid(); iter.Next() {
+		var status Status
+		if bytes.HasPrefix(iter.Key(), reservedStatusPrefix) || k.cdc.UnmarshalBinaryBare(iter.Value(), &status) != nil {
+			continue
+		}
+		keys = append(keys, iter.Key())
+	}
+	iter.Close()
+
+	for _, key := range keys {
+		store.Set(getReservedStatusKey(key), store.Get(key))
+		store.Delete(key)
+		ctx.Logger().Info("Moved status from the reserved key prefix", "key", fmt.Sprintf("%X", key))
+	}
+}
+
+// migrateNames indexes the names set before the name index, the invalid and
+// duplicate names are left unresolvable
+func (k Keeper) migrateNames(ctx sdk.Context) {
+	k.indexNames(ctx, func(addr sdk.AccAddress, name, reason string) {
+		ctx.Logger().Error("Skipped profile name", "address", addr, "name", name, "reason", reason)
+	})
+}
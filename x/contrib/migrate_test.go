@@ -0,0 +1,124 @@
+package contrib
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// setName sets the name of an account without indexing it, as the accounts
+// named before the name index
+func setName(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress, name string) {
+	acc := setAccount(ctx, am, addr, 0, nil)
+	acc.Name = name
+	am.SetAccount(ctx, acc)
+}
+
+func TestMigrateNames(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setName(ctx, am, addr1, "alice")
+	setName(ctx, am, addr2, "alice")
+	setName(ctx, am, addr3, "Bob")
+	setName(ctx, am, addr4, "carol")
+	require.Nil(t, k.GetNameOwner(ctx, "carol"))
+
+	BeginBlocker(ctx, k)
+
+	// the first account in address order keeps a duplicate name
+	first, second := addr1, addr2
+	if bytes.Compare(addr2, addr1) < 0 {
+		first, second = addr2, addr1
+	}
+	require.Equal(t, first, k.GetNameOwner(ctx, "alice"))
+	require.Nil(t, k.GetNameOwner(ctx, "Bob"))
+	require.Equal(t, addr4, k.GetNameOwner(ctx, "carol"))
+
+	// renaming the other account leaves the name to the first one
+	require.Nil(t, k.SetProfile(ctx, second, "alice2", nil, nil))
+	require.Equal(t, first, k.GetNameOwner(ctx, "alice"))
+	require.Equal(t, second, k.GetNameOwner(ctx, "alice2"))
+	require.Nil(t, k.SetProfile(ctx, first, "", nil, nil))
+	require.Nil(t, k.GetNameOwner(ctx, "alice"))
+
+	// the migration only runs once
+	require.Nil(t, k.SetProfile(ctx, addr4, "dave", nil, nil))
+	BeginBlocker(ctx, k)
+	require.Nil(t, k.GetNameOwner(ctx, "carol"))
+	require.Equal(t, addr4, k.GetNameOwner(ctx, "dave"))
+}
+
+func TestInitGenesisNames(t *testing.T) {
+	cases := []struct {
+		name  string
+		names []string
+		valid bool
+	}{
+		{"distinct names", []string{"alice", "bob"}, true},
+		{"duplicate names", []string{"alice", "alice"}, false},
+		{"invalid name", []string{"alice", "Bob"}, false},
+	}
+	for _, tc := range cases {
+		ctx, am, k, setter := createTestInput(t)
+		for i, name := range tc.names {
+			setName(ctx, am, []sdk.AccAddress{addr1, addr2}[i], name)
+		}
+
+		initGenesis := func() { InitGenesis(ctx, k, setter, DefaultGenesisState()) }
+		if !tc.valid {
+			require.Panics(t, initGenesis, tc.name)
+			continue
+		}
+		require.NotPanics(t, initGenesis, tc.name)
+		require.Equal(t, addr1, k.GetNameOwner(ctx, tc.names[0]), tc.name)
+		require.Equal(t, addr2, k.GetNameOwner(ctx, tc.names[1]), tc.name)
+
+		// a new chain has nothing to migrate
+		require.Nil(t, k.SetProfile(ctx, addr1, "", nil, nil))
+		BeginBlocker(ctx, k)
+		require.Nil(t, k.GetNameOwner(ctx, tc.names[0]), tc.name)
+	}
+}
+
+func TestMoveReservedStatuses(t *testing.T) {
+	ctx, am, k, _ := createTestInput(t)
+	setAccount(ctx, am, addr1, 0, nil)
+	setAccount(ctx, am, addr2, 0, nil)
+	store := ctx.KVStore(k.storeKey)
+
+	// statuses stored before the prefix was reserved, one of them on the key
+	// of a free quota
+	quotaKey := GetFreeQuotaKey(addr1)
+	reservedKeys := [][]byte{{ReservedKeyPrefix}, quotaKey}
+	for _, key := range reservedKeys {
+		setStatus(store, key, newPost("", addr1, addr2, genesisTime).NewStatus(), k.cdc)
+	}
+	tags := sdk.EmptyTags()
+	_, err := k.UpdateContrib(ctx, newPost("post", addr1, addr2, genesisTime), &tags)
+	require.Nil(t, err)
+	require.False(t, store.Has(getMigrationKey("names")))
+
+	BeginBlocker(ctx, k)
+
+	var keys [][]byte
+	k.IterateStatuses(ctx, func(key []byte, status Status) bool {
+		keys = append(keys, key)
+		require.Equal(t, addr1, status.(*PostStatus).Contributor)
+		return false
+	})
+	require.Equal(t, append(reservedKeys, []byte("post")), keys)
+	for _, key := range reservedKeys {
+		require.False(t, store.Has(key))
+		status, ok := DecodeStoreValue(k.cdc, getReservedStatusKey(key), store.Get(getReservedStatusKey(key)))
+		require.True(t, ok)
+		require.IsType(t, &PostStatus{}, status)
+	}
+
+	// the quota key is free for the quota again, and the migration data stays
+	k.setFreeQuota(ctx, addr1, FreeQuota{Day: 1, Used: 1})
+	require.Equal(t, FreeQuota{Day: 1, Used: 1}, k.getFreeQuota(ctx, addr1))
+	require.True(t, store.Has(getMigrationKey("names")))
+}
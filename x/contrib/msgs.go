@@ -1,7 +1,9 @@
 package contrib
 
 import (
+	"bytes"
 	"encoding/json"
+	"time"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 )
@@ -62,3 +64,268 @@ func (msg MsgContrib) GetSigners() []sdk.AccAddress {
 	}
 	return addrs
 }
+
+// MsgDelegateRepute - lend the voting weight of an account to another one
+type MsgDelegateRepute struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+	Delegatee sdk.AccAddress `json:"delegatee"`
+}
+
+var _ sdk.Msg = MsgDelegateRepute{}
+
+// NewMsgDelegateRepute - construct a repute delegation msg
+func NewMsgDelegateRepute(delegator, delegatee sdk.AccAddress) MsgDelegateRepute {
+	return MsgDelegateRepute{Delegator: delegator, Delegatee: delegatee}
+}
+
+// Implements Msg.
+func (msg MsgDelegateRepute) Type() string { return "contrib" }
+
+// Implements Msg.
+func (msg MsgDelegateRepute) ValidateBasic() sdk.Error {
+	if len(msg.Delegator) == 0 {
+		return sdk.ErrInvalidAddress(msg.Delegator.String())
+	}
+	if len(msg.Delegatee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Delegatee.String())
+	}
+	if bytes.Equal(msg.Delegator, msg.Delegatee) {
+		return ErrDelegationCycle(DefaultCodespace, msg.Delegator, msg.Delegatee)
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgDelegateRepute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgDelegateRepute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+// MsgUndelegateRepute - revoke the repute delegation of an account
+type MsgUndelegateRepute struct {
+	Delegator sdk.AccAddress `json:"delegator"`
+}
+
+var _ sdk.Msg = MsgUndelegateRepute{}
+
+// NewMsgUndelegateRepute - construct a repute delegation revoke msg
+func NewMsgUndelegateRepute(delegator sdk.AccAddress) MsgUndelegateRepute {
+	return MsgUndelegateRepute{Delegator: delegator}
+}
+
+// Implements Msg.
+func (msg MsgUndelegateRepute) Type() string { return "contrib" }
+
+// Implements Msg.
+func (msg MsgUndelegateRepute) ValidateBasic() sdk.Error {
+	if len(msg.Delegator) == 0 {
+		return sdk.ErrInvalidAddress(msg.Delegator.String())
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgUndelegateRepute) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgUndelegateRepute) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Delegator}
+}
+
+// MsgCreateBounty - escrow a reward for requested content
+type MsgCreateBounty struct {
+	Creator     sdk.AccAddress `json:"creator"`
+	Description string         `json:"description"`
+	Reward      sdk.Coins      `json:"reward"`
+	Expiry      time.Time      `json:"expiry"`
+}
+
+var _ sdk.Msg = MsgCreateBounty{}
+
+// NewMsgCreateBounty - construct a bounty creation msg
+func NewMsgCreateBounty(creator sdk.AccAddress, description string, reward sdk.Coins, expiry time.Time) MsgCreateBounty {
+	return MsgCreateBounty{Creator: creator, Description: description, Reward: reward, Expiry: expiry}
+}
+
+// Implements Msg.
+func (msg MsgCreateBounty) Type() string { return "contrib" }
+
+// Implements Msg.
+func (msg MsgCreateBounty) ValidateBasic() sdk.Error {
+	if len(msg.Creator) == 0 {
+		return sdk.ErrInvalidAddress(msg.Creator.String())
+	}
+	if len(msg.Description) == 0 {
+		return ErrInvalidBounty(DefaultCodespace, "missing description")
+	}
+	if len(msg.Description) > MaxBountyDescriptionSize {
+		return ErrContentTooLarge(DefaultCodespace, len(msg.Description), MaxBountyDescriptionSize)
+	}
+	if !msg.Reward.IsValid() || !msg.Reward.IsPositive() {
+		return ErrInvalidBounty(DefaultCodespace, "reward must be positive: "+msg.Reward.String())
+	}
+	if msg.Expiry.IsZero() {
+		return ErrInvalidBounty(DefaultCodespace, "missing expiry")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgCreateBounty) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgCreateBounty) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Creator}
+}
+
+// MsgSubmitBounty - submit a post against a bounty
+type MsgSubmitBounty struct {
+	Contributor sdk.AccAddress `json:"contributor"`
+	BountyID    int64          `json:"bounty_id"`
+	PostKey     []byte         `json:"post_key"`
+}
+
+var _ sdk.Msg = MsgSubmitBounty{}
+
+// NewMsgSubmitBounty - construct a bounty submission msg
+func NewMsgSubmitBounty(contributor sdk.AccAddress, id int64, postKey []byte) MsgSubmitBounty {
+	return MsgSubmitBounty{Contributor: contributor, BountyID: id, PostKey: postKey}
+}
+
+// Implements Msg.
+func (msg MsgSubmitBounty) Type() string { return "contrib" }
+
+// Implements Msg.
+func (msg MsgSubmitBounty) ValidateBasic() sdk.Error {
+	if len(msg.Contributor) == 0 {
+		return sdk.ErrInvalidAddress(msg.Contributor.String())
+	}
+	if len(msg.PostKey) == 0 {
+		return ErrInvalidBounty(DefaultCodespace, "missing post key")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgSubmitBounty) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgSubmitBounty) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Contributor}
+}
+
+// MsgAwardBounty - pay a bounty to the author of a submitted post
+type MsgAwardBounty struct {
+	Awarder  sdk.AccAddress `json:"awarder"`
+	BountyID int64          `json:"bounty_id"`
+	PostKey  []byte         `json:"post_key"`
+}
+
+var _ sdk.Msg = MsgAwardBounty{}
+
+// NewMsgAwardBounty - construct a bounty award msg
+func NewMsgAwardBounty(awarder sdk.AccAddress, id int64, postKey []byte) MsgAwardBounty {
+	return MsgAwardBounty{Awarder: awarder, BountyID: id, PostKey: postKey}
+}
+
+// Implements Msg.
+func (msg MsgAwardBounty) Type() string { return "contrib" }
+
+// Implements Msg.
+func (msg MsgAwardBounty) ValidateBasic() sdk.Error {
+	if len(msg.Awarder) == 0 {
+		return sdk.ErrInvalidAddress(msg.Awarder.String())
+	}
+	if len(msg.PostKey) == 0 {
+		return ErrInvalidBounty(DefaultCodespace, "missing post key")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgAwardBounty) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgAwardBounty) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Awarder}
+}
+
+// MsgSetProfile - set the name, bio and avatar of an account
+type MsgSetProfile struct {
+	Owner  sdk.AccAddress `json:"owner"`
+	Name   string         `json:"name"`
+	Bio    []byte         `json:"bio"`
+	Avatar []byte         `json:"avatar"`
+}
+
+var _ sdk.Msg = MsgSetProfile{}
+
+// NewMsgSetProfile - construct a profile msg
+func NewMsgSetProfile(owner sdk.AccAddress, name string, bio, avatar []byte) MsgSetProfile {
+	return MsgSetProfile{Owner: owner, Name: name, Bio: bio, Avatar: avatar}
+}
+
+// Implements Msg.
+func (msg MsgSetProfile) Type() string { return "contrib" }
+
+// Implements Msg.
+func (msg MsgSetProfile) ValidateBasic() sdk.Error {
+	if len(msg.Owner) == 0 {
+		return sdk.ErrInvalidAddress(msg.Owner.String())
+	}
+	if msg.Name != "" {
+		if err := ValidateName(msg.Name); err != nil {
+			return err
+		}
+	}
+	if len(msg.Bio) > MaxProfileHash || len(msg.Avatar) > MaxProfileHash {
+		return ErrInvalidProfile(DefaultCodespace, "bio and avatar must be hashes")
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgSetProfile) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgSetProfile) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Owner}
+}
@@ -0,0 +1,93 @@
+package contrib
+
+import (
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// nolint
+const (
+	GasPriceDenomKey  = "contrib/GasPriceDenom"
+	MinGasPriceKey    = "contrib/MinGasPrice"
+	DiscountReputeKey = "contrib/DiscountRepute"
+	DiscountRateKey   = "contrib/DiscountRate"
+	FreeReputeKey     = "contrib/FreeRepute"
+	FreeDailyQuotaKey = "contrib/FreeDailyQuota"
+
+	BountyReputeBonusKey       = "contrib/BountyReputeBonus"
+	BountyArbitrationPeriodKey = "contrib/BountyArbitrationPeriod"
+
+	ReputeLedgerMaxEntriesKey = "contrib/ReputeLedgerMaxEntries"
+	ReputeLedgerMaxAgeKey     = "contrib/ReputeLedgerMaxAge"
+)
+
+// GasPriceDenom - denom the minimum fee is paid in
+func (k Keeper) GasPriceDenom(ctx sdk.Context) string {
+	return k.params.GetStringWithDefault(ctx, GasPriceDenomKey, defaultGasPriceDenom)
+}
+
+// MinGasPrice - minimum fee per unit of gas, zero disables the minimum fee
+func (k Keeper) MinGasPrice(ctx sdk.Context) sdk.Rat {
+	return k.params.GetRatWithDefault(ctx, MinGasPriceKey, defaultMinGasPrice)
+}
+
+// DiscountRepute - repute from which the gas price is discounted
+func (k Keeper) DiscountRepute(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, DiscountReputeKey, defaultDiscountRepute)
+}
+
+// DiscountRate - fraction of the gas price taken off above DiscountRepute
+func (k Keeper) DiscountRate(ctx sdk.Context) sdk.Rat {
+	return k.params.GetRatWithDefault(ctx, DiscountRateKey, defaultDiscountRate)
+}
+
+// FreeRepute - repute from which contrib txs are free up to FreeDailyQuota
+func (k Keeper) FreeRepute(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, FreeReputeKey, defaultFreeRepute)
+}
+
+// FreeDailyQuota - number of free contrib txs per day
+func (k Keeper) FreeDailyQuota(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, FreeDailyQuotaKey, defaultFreeDailyQuota)
+}
+
+// BountyReputeBonus - repute given to the winner of a bounty
+func (k Keeper) BountyReputeBonus(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, BountyReputeBonusKey, defaultBountyReputeBonus)
+}
+
+// BountyArbitrationPeriod - time after expiry during which admins can award
+// a bounty before it is refunded
+func (k Keeper) BountyArbitrationPeriod(ctx sdk.Context) time.Duration {
+	return time.Duration(k.params.GetInt64WithDefault(ctx, BountyArbitrationPeriodKey, defaultBountyArbitrationPeriod)) * time.Second
+}
+
+// ReputeLedgerMaxEntries - number of repute ledger entries kept per account,
+// zero keeps them all
+func (k Keeper) ReputeLedgerMaxEntries(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, ReputeLedgerMaxEntriesKey, defaultReputeLedgerMaxEntries)
+}
+
+// ReputeLedgerMaxAge - number of blocks a repute ledger entry is kept, zero
+// keeps them forever
+func (k Keeper) ReputeLedgerMaxAge(ctx sdk.Context) int64 {
+	return k.params.GetInt64WithDefault(ctx, ReputeLedgerMaxAgeKey, defaultReputeLedgerMaxAge)
+}
+
+// the defaults apply when the genesis sets no params, they cannot be changed
+// afterwards. A zero gas price keeps feeless txs valid.
+var (
+	defaultGasPriceDenom        = "steak"
+	defaultMinGasPrice          = sdk.ZeroRat()
+	defaultDiscountRepute int64 = 100
+	defaultDiscountRate         = sdk.NewRat(1, 2)
+	defaultFreeRepute     int64 = 1000
+	defaultFreeDailyQuota int64 = 10
+
+	defaultBountyReputeBonus       int64 = 10
+	defaultBountyArbitrationPeriod int64 = 60 * 60 * 24 * 7
+
+	defaultReputeLedgerMaxEntries int64 = 1000
+	defaultReputeLedgerMaxAge     int64
+)
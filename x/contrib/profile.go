@@ -0,0 +1,102 @@
+package contrib
+
+import (
+	"bytes"
+	"regexp"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/forbole/forboled/types"
+)
+
+// Limits of the profile fields
+const (
+	MinNameLength  = 3
+	MaxNameLength  = 32
+	MaxProfileHash = 64
+)
+
+var nameRegexp = regexp.MustCompile(`^[a-z][a-z0-9_\-]*$`)
+
+var nameKeyPrefix = []byte{ReservedKeyPrefix, 0x0A} // name -> address
+
+// GetNameKey returns the store key resolving a profile name
+func GetNameKey(name string) []byte {
+	return append(nameKeyPrefix, []byte(name)...)
+}
+
+// ValidateName checks that name can be used as a profile name. Names that
+// are also valid addresses are rejected so that resolving is unambiguous.
+func ValidateName(name string) sdk.Error {
+	if len(name) < MinNameLength || len(name) > MaxNameLength {
+		return ErrInvalidProfile(DefaultCodespace, "name must be between 3 and 32 characters")
+	}
+	if !nameRegexp.MatchString(name) {
+		return ErrInvalidProfile(DefaultCodespace, "name must start with a letter and only contain a-z, 0-9, _ and -")
+	}
+	if _, err := sdk.AccAddressFromBech32(name); err == nil {
+		return ErrInvalidProfile(DefaultCodespace, "name cannot be an address")
+	}
+	return nil
+}
+
+// GetNameOwner returns the account holding name
+func (k Keeper) GetNameOwner(ctx sdk.Context, name string) sdk.AccAddress {
+	bz := ctx.KVStore(k.storeKey).Get(GetNameKey(name))
+	if bz == nil {
+		return nil
+	}
+	return sdk.AccAddress(bz)
+}
+
+// SetProfile sets the profile of owner. An empty name releases the current
+// name of owner.
+func (k Keeper) SetProfile(ctx sdk.Context, owner sdk.AccAddress, name string, bio, avatar []byte) sdk.Error {
+	acc, ok := k.am.GetAccount(ctx, owner).(*types.ReputeAccount)
+	if !ok {
+		return sdk.ErrUnknownAddress(owner.String())
+	}
+
+	if name != acc.GetName() {
+		if name != "" {
+			if holder := k.GetNameOwner(ctx, name); holder != nil && !bytes.Equal(holder, owner) {
+				return ErrNameTaken(DefaultCodespace, name)
+			}
+			ctx.KVStore(k.storeKey).Set(GetNameKey(name), owner.Bytes())
+		}
+		// a legacy account can keep a name the index gave to another account
+		if old := acc.GetName(); old != "" && bytes.Equal(k.GetNameOwner(ctx, old), owner) {
+			ctx.KVStore(k.storeKey).Delete(GetNameKey(old))
+		}
+		acc.SetName(name)
+	}
+
+	acc.Bio = bio
+	acc.Avatar = avatar
+	k.am.SetAccount(ctx, acc)
+	return nil
+}
+
+// indexNames indexes the names of the accounts in address order. The names
+// that are invalid or already taken are passed to skip instead.
+func (k Keeper) indexNames(ctx sdk.Context, skip func(addr sdk.AccAddress, name, reason string)) {
+	store := ctx.KVStore(k.storeKey)
+	k.am.IterateAccounts(ctx, func(acc auth.Account) bool {
+		racc, ok := acc.(*types.ReputeAccount)
+		if !ok || racc.GetName() == "" {
+			return false
+		}
+		name := racc.GetName()
+		if ValidateName(name) != nil {
+			skip(racc.GetAddress(), name, "invalid name")
+			return false
+		}
+		if holder := store.Get(GetNameKey(name)); holder != nil && !bytes.Equal(holder, racc.GetAddress()) {
+			skip(racc.GetAddress(), name, "name already taken")
+			return false
+		}
+		store.Set(GetNameKey(name), racc.GetAddress().Bytes())
+		return false
+	})
+}
@@ -0,0 +1,159 @@
+package contrib
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// The optional fields a contrib type can read
+const (
+	FieldContent = "content"
+	FieldVote    = "vote"
+	FieldTip     = "tip"
+)
+
+// ContribFields are the values a contrib is built from
+type ContribFields struct {
+	Key         []byte
+	Contributor sdk.AccAddress
+	Recipient   sdk.AccAddress
+	Time        time.Time
+	Content     []byte
+	Vote        int64
+	Tip         sdk.Coins
+}
+
+// ContribTypeInfo describes a contrib type. The codec, the CLI and the REST
+// server are driven by the registered types, adding a type only takes a
+// RegisterContribType call.
+type ContribTypeInfo struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Fields      []string `json:"fields"` // optional fields read besides key, recipient and time
+
+	New     func(ContribFields) Contrib `json:"-"`
+	Contrib Contrib                     `json:"-"` // registered with the codec as contrib/<Name>
+	Status  Status                      `json:"-"` // registered with the codec as contrib/<Name>Status
+}
+
+// HasField tells if the type reads field
+func (t ContribTypeInfo) HasField(field string) bool {
+	for _, f := range t.Fields {
+		if f == field {
+			return true
+		}
+	}
+	return false
+}
+
+var contribTypes []ContribTypeInfo
+
+// RegisterContribType adds a contrib type, it must be called before the
+// codec is built
+func RegisterContribType(t ContribTypeInfo) {
+	if _, ok := GetContribType(t.Name); ok {
+		panic(fmt.Sprintf("contrib type %s already registered", t.Name))
+	}
+	contribTypes = append(contribTypes, t)
+}
+
+// ContribTypes returns the registered contrib types
+func ContribTypes() []ContribTypeInfo {
+	return append([]ContribTypeInfo{}, contribTypes...)
+}
+
+// ContribTypeNames returns the names of the registered contrib types
+func ContribTypeNames() []string {
+	names := make([]string, len(contribTypes))
+	for i, t := range contribTypes {
+		names[i] = t.Name
+	}
+	return names
+}
+
+// GetContribType returns the contrib type named name, in any case
+func GetContribType(name string) (ContribTypeInfo, bool) {
+	for _, t := range contribTypes {
+		if strings.EqualFold(t.Name, name) {
+			return t, true
+		}
+	}
+	return ContribTypeInfo{}, false
+}
+
+func registerContribTypes(cdc *wire.Codec) {
+	for _, t := range contribTypes {
+		cdc.RegisterConcrete(t.Contrib, "contrib/"+t.Name, nil)
+	}
+}
+
+func registerStatusTypes(cdc *wire.Codec) {
+	for _, t := range contribTypes {
+		cdc.RegisterConcrete(t.Status, "contrib/"+t.Name+"Status", nil)
+	}
+}
+
+func init() {
+	RegisterContribType(ContribTypeInfo{
+		Name:        "Invite",
+		Description: "invite a new account",
+		Fields:      []string{FieldContent},
+		New: func(f ContribFields) Contrib {
+			return Invite{BaseContrib2{BaseContrib{f.Key, f.Contributor, f.Time}, f.Recipient}, f.Content}
+		},
+		Contrib: &Invite{},
+		Status:  &InviteStatus{},
+	})
+	RegisterContribType(ContribTypeInfo{
+		Name:        "Recommend",
+		Description: "recommend an account",
+		Fields:      []string{FieldContent, FieldTip},
+		New: func(f ContribFields) Contrib {
+			return Recommend{BaseContrib2{BaseContrib{f.Key, f.Contributor, f.Time}, f.Recipient}, f.Content, f.Tip}
+		},
+		Contrib: &Recommend{},
+		Status:  &RecommendStatus{},
+	})
+	RegisterContribType(ContribTypeInfo{
+		Name:        "Vote",
+		Description: "upvote or downvote a contrib of an account",
+		Fields:      []string{FieldContent, FieldVote},
+		New: func(f ContribFields) Contrib {
+			return Vote{BaseContrib3{BaseContrib{f.Key, f.Contributor, f.Time}, f.Recipient, f.Vote}, f.Content}
+		},
+		Contrib: &Vote{},
+		Status:  &VoteStatus{},
+	})
+	RegisterContribType(ContribTypeInfo{
+		Name:        "Post",
+		Description: "post content, addressed to an account",
+		Fields:      []string{FieldContent, FieldTip},
+		New: func(f ContribFields) Contrib {
+			return Post{BaseContrib2{BaseContrib{f.Key, f.Contributor, f.Time}, f.Recipient}, f.Content, f.Tip}
+		},
+		Contrib: &Post{},
+		Status:  &PostStatus{},
+	})
+	RegisterContribType(ContribTypeInfo{
+		Name:        "Follow",
+		Description: "follow the posts of an account",
+		New: func(f ContribFields) Contrib {
+			return Follow{BaseContrib2{BaseContrib{f.Key, f.Contributor, f.Time}, f.Recipient}}
+		},
+		Contrib: &Follow{},
+		Status:  &FollowStatus{},
+	})
+	RegisterContribType(ContribTypeInfo{
+		Name:        "Unfollow",
+		Description: "stop following the posts of an account",
+		New: func(f ContribFields) Contrib {
+			return Unfollow{BaseContrib2{BaseContrib{f.Key, f.Contributor, f.Time}, f.Recipient}}
+		},
+		Contrib: &Unfollow{},
+		Status:  &UnfollowStatus{},
+	})
+}
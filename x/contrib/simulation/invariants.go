@@ -0,0 +1,136 @@
+package simulation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+)
+
+// AllInvariants runs all invariants of the contrib module.
+// Currently: repute sum, repute ledger, status consistency, follow index
+func AllInvariants(k contrib.Keeper, am auth.AccountMapper) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ReputeInvariant(k, am)(t, app, log)
+		LedgerInvariant(k, am)(t, app, log)
+		StatusInvariant(k, am)(t, app, log)
+		FollowInvariant(k, am)(t, app, log)
+	}
+}
+
+// ReputeInvariant checks that the repute of every account is the total
+// score of the statuses it contributed. It holds as long as repute is only
+// earned with contribs, e.g. without bounties or genesis repute.
+func ReputeInvariant(k contrib.Keeper, am auth.AccountMapper) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ctx := app.NewContext(false, abci.Header{})
+
+		scores := make(map[string]int64)
+		var total int64
+		k.IterateStatuses(ctx, func(key []byte, status contrib.Status) bool {
+			scores[string(statusAddress(status, "Contributor"))] += status.GetScore()
+			total += status.GetScore()
+			return false
+		})
+
+		var repute int64
+		am.IterateAccounts(ctx, func(acc auth.Account) bool {
+			racc, ok := acc.(*types.ReputeAccount)
+			require.True(t, ok, "account %s is a %T, not a repute account\n%s", acc.GetAddress(), acc, log)
+			require.Equal(t, scores[string(racc.Address)], racc.Repute,
+				"repute of %s is not the score of its contribs\n%s", racc.Address, log)
+			repute += racc.Repute
+			return false
+		})
+		require.Equal(t, total, repute, "repute sum is not the score sum\n%s", log)
+	}
+}
+
+// LedgerInvariant checks that the repute ledger of every account explains
+// its repute, unless entries were pruned
+func LedgerInvariant(k contrib.Keeper, am auth.AccountMapper) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ctx := app.NewContext(false, abci.Header{})
+
+		am.IterateAccounts(ctx, func(acc auth.Account) bool {
+			if !k.IsReputeLedgerComplete(ctx, acc.GetAddress()) {
+				return false
+			}
+			var repute int64
+			for _, entry := range k.GetReputeLedger(ctx, acc.GetAddress()) {
+				require.NotEqual(t, int64(0), entry.Delta, "ledger of %s has an empty entry\n%s", acc.GetAddress(), log)
+				repute += entry.Delta
+			}
+			require.Equal(t, acc.(*types.ReputeAccount).Repute, repute,
+				"ledger of %s does not add up to its repute\n%s", acc.GetAddress(), log)
+			return false
+		})
+	}
+}
+
+// StatusInvariant checks that every status decodes, has a known contributor
+// and recipient, a non negative score and valid tips
+func StatusInvariant(k contrib.Keeper, am auth.AccountMapper) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ctx := app.NewContext(false, abci.Header{})
+
+		k.IterateStatuses(ctx, func(key []byte, status contrib.Status) bool {
+			require.NotEqual(t, contrib.ReservedKeyPrefix, key[0], "status stored under a reserved key %X\n%s", key, log)
+			require.True(t, status.GetScore() >= 0, "status %X has a negative score %d\n%s", key, status.GetScore(), log)
+			if _, ok := status.(*contrib.InviteStatus); ok {
+				require.Equal(t, int64(1), status.GetScore(), "invite %X is scored more than once\n%s", key, log)
+			}
+
+			contributor := statusAddress(status, "Contributor")
+			require.NotNil(t, am.GetAccount(ctx, contributor), "status %X has an unknown contributor %s\n%s", key, contributor, log)
+			if recipient := statusAddress(status, "Recipient"); recipient != nil {
+				require.NotNil(t, am.GetAccount(ctx, recipient), "status %X has an unknown recipient %s\n%s", key, recipient, log)
+			}
+
+			v := reflect.Indirect(reflect.ValueOf(status)).FieldByName("TipTotal")
+			if v.IsValid() {
+				tips := v.Interface().(sdk.Coins)
+				require.True(t, tips.IsValid() && tips.IsNotNegative(), "status %X has invalid tips %v\n%s", key, tips, log)
+			}
+			return false
+		})
+	}
+}
+
+// FollowInvariant checks that the following and followers indexes match
+func FollowInvariant(k contrib.Keeper, am auth.AccountMapper) simulation.Invariant {
+	return func(t *testing.T, app *baseapp.BaseApp, log string) {
+		ctx := app.NewContext(false, abci.Header{})
+
+		am.IterateAccounts(ctx, func(acc auth.Account) bool {
+			for _, followee := range k.GetFollowing(ctx, acc.GetAddress()) {
+				require.Contains(t, k.GetFollowers(ctx, followee), acc.GetAddress(),
+					"%s follows %s but is not one of its followers\n%s", acc.GetAddress(), followee, log)
+			}
+			for _, follower := range k.GetFollowers(ctx, acc.GetAddress()) {
+				require.Contains(t, k.GetFollowing(ctx, follower), acc.GetAddress(),
+					"%s is a follower of %s but does not follow it\n%s", follower, acc.GetAddress(), log)
+			}
+			return false
+		})
+	}
+}
+
+// statusAddress returns the address held in the field of a status, or nil
+// when the status has no such field
+func statusAddress(status contrib.Status, field string) sdk.AccAddress {
+	v := reflect.Indirect(reflect.ValueOf(status)).FieldByName(field)
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Interface().(sdk.AccAddress)
+}
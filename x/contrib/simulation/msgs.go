@@ -0,0 +1,155 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/baseapp"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mock"
+	"github.com/cosmos/cosmos-sdk/x/mock/simulation"
+	"github.com/tendermint/tendermint/crypto"
+
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib"
+)
+
+const (
+	// numContribKeys is the number of keys each account uses per contrib
+	// type, small enough for contribs to update their statuses
+	numContribKeys = 4
+
+	// numSharedKeys is the number of keys shared by all the contribs
+	numSharedKeys = 16
+
+	// tipDenom is the denomination of the tips
+	tipDenom = "steak"
+)
+
+// SimulateContribs returns an operation for each registered contrib type
+func SimulateContribs(cdc *wire.Codec, m auth.AccountMapper) []simulation.TestAndRunTx {
+	var ops []simulation.TestAndRunTx
+	for _, name := range contrib.ContribTypeNames() {
+		ops = append(ops, SimulateMsgContrib(cdc, m, name))
+	}
+	return ops
+}
+
+// SimulateMsgContrib delivers a MsgContrib holding a random contrib of
+// ctbtype from a random account. The tx goes through the app encoded, like
+// on chain. A contrib may be rejected, but it must never panic, and when it
+// is accepted the repute and coins move as its result tells.
+func SimulateMsgContrib(cdc *wire.Codec, m auth.AccountMapper, ctbtype string) simulation.TestAndRunTx {
+	info, ok := contrib.GetContribType(ctbtype)
+	if !ok {
+		panic(fmt.Sprintf("unknown contrib type %s", ctbtype))
+	}
+	var typeIndex int
+	for i, name := range contrib.ContribTypeNames() {
+		if name == info.Name {
+			typeIndex = i
+		}
+	}
+
+	// invites are meant for new accounts, the other contribs for known ones
+	fresh := 0.1
+	if info.Name == "Invite" {
+		fresh = 0.9
+	}
+
+	return func(t *testing.T, r *rand.Rand, app *baseapp.BaseApp, ctx sdk.Context, keys []crypto.PrivKey, log string, event func(string)) (action string, err sdk.Error) {
+		key := simulation.RandomKey(r, keys)
+		contributor := sdk.AccAddress(key.PubKey().Address())
+		acc := m.GetAccount(ctx, contributor)
+
+		fields := contrib.ContribFields{
+			Key:         randomContribKey(r, typeIndex, contributor),
+			Contributor: contributor,
+			Recipient:   randomRecipient(r, keys, fresh),
+			Time:        ctx.BlockHeader().Time.Add(time.Duration(r.Intn(3600)) * time.Second),
+		}
+		if info.HasField(contrib.FieldContent) {
+			fields.Content = []byte(simulation.RandStringOfLength(r, r.Intn(64)))
+		}
+		if info.HasField(contrib.FieldVote) {
+			fields.Vote = []int64{1, -1}[r.Intn(2)]
+		}
+		if info.HasField(contrib.FieldTip) && r.Intn(3) == 0 {
+			balance := acc.GetCoins().AmountOf(tipDenom)
+			if balance.GT(sdk.ZeroInt()) {
+				amount := simulation.RandomAmount(r, balance)
+				if amount.GT(sdk.ZeroInt()) {
+					fields.Tip = sdk.Coins{sdk.NewCoin(tipDenom, amount)}
+				}
+			}
+		}
+		ctb := info.New(fields)
+		msg := contrib.NewMsgContrib([]contrib.Contrib{ctb})
+
+		contributorBefore := acc.(*types.ReputeAccount)
+		var recipientCoins sdk.Coins
+		if recipient := m.GetAccount(ctx, fields.Recipient); recipient != nil {
+			recipientCoins = recipient.GetCoins()
+		}
+
+		tx := mock.GenTx([]sdk.Msg{msg}, []int64{acc.GetAccountNumber()}, []int64{acc.GetSequence()}, key)
+		res := app.DeliverTx(cdc.MustMarshalBinary(tx))
+		require.NotEqual(t, sdk.ErrInternal("").ABCICode(), sdk.ABCICodeType(res.Code),
+			"contrib %v panicked: %s\n%s", ctb, res.Log, log)
+
+		ok := res.IsOK()
+		event(fmt.Sprintf("contrib/%s/%v", info.Name, ok))
+		action = fmt.Sprintf("SimulateMsgContrib: ok %v, contrib %s %X, log %s", ok, info.Name, fields.Key, res.Log)
+		if !ok {
+			return action, nil
+		}
+
+		var results []contrib.ContribResult
+		require.Nil(t, cdc.UnmarshalBinary(res.Data, &results), log)
+		require.Len(t, results, 1, log)
+		result := results[0]
+		require.Equal(t, info.Name, result.Type, log)
+
+		contributorAfter := m.GetAccount(ctx, contributor).(*types.ReputeAccount)
+		require.Equal(t, contributorBefore.Repute+result.ScoreDelta, contributorAfter.Repute,
+			"repute of %s did not move by the score delta\n%s", contributor, action+"\n"+log)
+		require.Equal(t, contributorAfter.Repute, result.Repute, log)
+
+		if len(fields.Tip) > 0 && !bytes.Equal(contributor, fields.Recipient) {
+			require.Equal(t, contributorBefore.GetCoins().Minus(fields.Tip), contributorAfter.GetCoins(),
+				"tipper %s did not pay the tip\n%s", contributor, action+"\n"+log)
+			require.Equal(t, recipientCoins.Plus(fields.Tip), m.GetAccount(ctx, fields.Recipient).GetCoins(),
+				"recipient %s did not get the tip\n%s", fields.Recipient, action+"\n"+log)
+		}
+		return action, nil
+	}
+}
+
+// randomContribKey mostly returns one of the keys of contributor for the
+// contrib type, and sometimes a key shared by all the contribs
+func randomContribKey(r *rand.Rand, typeIndex int, contributor sdk.AccAddress) []byte {
+	if r.Intn(10) == 0 {
+		return []byte{byte(1 + r.Intn(numSharedKeys))}
+	}
+	key := []byte{0x80 + byte(typeIndex)}
+	key = append(key, contributor[:4]...)
+	return append(key, byte(r.Intn(numContribKeys)))
+}
+
+// randomRecipient returns the address of a random key, or with probability
+// fresh an address no key owns
+func randomRecipient(r *rand.Rand, keys []crypto.PrivKey, fresh float64) sdk.AccAddress {
+	if r.Float64() < fresh {
+		addr := make([]byte, sdk.AddrLen)
+		r.Read(addr)
+		return sdk.AccAddress(addr)
+	}
+	return sdk.AccAddress(simulation.RandomKey(r, keys).PubKey().Address())
+}
@@ -9,6 +9,14 @@ import (
 	"github.com/cosmos/cosmos-sdk/x/auth"
 )
 
+// Maximum content size in bytes accepted for each contrib type
+const (
+	MaxInviteContentSize    = 256
+	MaxRecommendContentSize = 1024
+	MaxPostContentSize      = 4096
+	MaxVoteContentSize      = 256
+)
+
 //----------------------------------------
 // Contrib
 
@@ -27,17 +35,17 @@ type Contribs []Contrib
 
 // ValidateBasic - validate transaction contribs
 func (contribs Contribs) ValidateBasic() sdk.Error {
-	// m := make(map[string]struct{})
+	m := make(map[string]struct{})
 	for _, ctb := range contribs {
 		err := ctb.ValidateBasic()
 		if err != nil {
 			return err
 		}
-		// _, found := m[string(ctb.Key)]
-		// if found {
-		// 	return ErrInvalidContrib(DefaultCodespace, "duplicate key")
-		// }
-		// m[string(ctb.Key)] = struct{}{}
+		_, found := m[string(ctb.GetKey())]
+		if found {
+			return ErrInvalidContrib(DefaultCodespace, "duplicate key")
+		}
+		m[string(ctb.GetKey())] = struct{}{}
 	}
 
 	return nil
@@ -74,6 +82,9 @@ func (ctb BaseContrib) ValidateBasic() sdk.Error {
 	if len(ctb.Key) == 0 {
 		return ErrInvalidContrib(DefaultCodespace, ctb.String())
 	}
+	if ctb.Key[0] == ReservedKeyPrefix {
+		return ErrInvalidContrib(DefaultCodespace, "key prefix is reserved")
+	}
 	if len(ctb.Contributor) == 0 {
 		return sdk.ErrInvalidAddress(ctb.Contributor.String())
 	}
@@ -89,7 +100,26 @@ func (ctb BaseContrib) ValidateAccounts(ctx sdk.Context, am auth.AccountMapper)
 }
 
 func (ctb BaseContrib) String() string {
-	return fmt.Sprintf("%v", ctb)
+	return fmt.Sprintf("BaseContrib{%X %v %v}", ctb.Key, ctb.Contributor, ctb.Time)
+}
+
+// validateTip checks the optional tip of a contrib
+func validateTip(tip sdk.Coins) sdk.Error {
+	if len(tip) == 0 {
+		return nil
+	}
+	if !tip.IsValid() || !tip.IsPositive() {
+		return ErrInvalidContrib(DefaultCodespace, "invalid tip "+tip.String())
+	}
+	return nil
+}
+
+// validateContent checks the content against the size limit of its contrib type
+func validateContent(content []byte, maxSize int) sdk.Error {
+	if len(content) > maxSize {
+		return ErrContentTooLarge(DefaultCodespace, len(content), maxSize)
+	}
+	return nil
 }
 
 type BaseContrib2 struct {
@@ -97,6 +127,8 @@ type BaseContrib2 struct {
 	Recipient sdk.AccAddress `json:"recipient"`
 }
 
+func (ctb BaseContrib2) GetRecipient() sdk.AccAddress { return ctb.Recipient }
+
 func (ctb BaseContrib2) AppendTags(tags *sdk.Tags) {
 	*tags = append(*tags, sdk.MakeTag("contributor", ctb.Contributor.Bytes()), sdk.MakeTag("recipient", ctb.Recipient.Bytes()))
 }
@@ -106,11 +138,8 @@ func (ctb BaseContrib2) NewStatus() Status {
 }
 
 func (ctb BaseContrib2) ValidateBasic() sdk.Error {
-	if len(ctb.Key) == 0 {
-		return ErrInvalidContrib(DefaultCodespace, ctb.String())
-	}
-	if len(ctb.Contributor) == 0 {
-		return sdk.ErrInvalidAddress(ctb.Contributor.String())
+	if err := ctb.BaseContrib.ValidateBasic(); err != nil {
+		return err
 	}
 	if len(ctb.Recipient) == 0 {
 		return sdk.ErrInvalidAddress(ctb.Recipient.String())
@@ -136,6 +165,13 @@ type Invite struct {
 	Content []byte `json:"content"`
 }
 
+func (ctb Invite) ValidateBasic() sdk.Error {
+	if err := ctb.BaseContrib2.ValidateBasic(); err != nil {
+		return err
+	}
+	return validateContent(ctb.Content, MaxInviteContentSize)
+}
+
 func (ctb Invite) NewStatus() Status {
 	return &InviteStatus{BaseStatus: BaseStatus{Score: 1, Contributor: ctb.Contributor, Time: ctb.Time}, Recipient: ctb.Recipient}
 }
@@ -156,9 +192,22 @@ func (ctb Invite) ValidateAccounts(ctx sdk.Context, am auth.AccountMapper) (auth
 
 type Recommend struct {
 	BaseContrib2
-	Content []byte `json:"content"`
+	Content []byte    `json:"content"`
+	Tip     sdk.Coins `json:"tip"`
 }
 
+func (ctb Recommend) ValidateBasic() sdk.Error {
+	if err := ctb.BaseContrib2.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := validateTip(ctb.Tip); err != nil {
+		return err
+	}
+	return validateContent(ctb.Content, MaxRecommendContentSize)
+}
+
+func (ctb Recommend) GetTip() sdk.Coins { return ctb.Tip }
+
 func (ctb Recommend) NewStatus() Status {
 	return &RecommendStatus{BaseStatus: BaseStatus{Score: 1, Contributor: ctb.Contributor, Time: ctb.Time}, Recipient: ctb.Recipient}
 }
@@ -181,9 +230,22 @@ func (ctb Recommend) ValidateAccounts(ctx sdk.Context, am auth.AccountMapper) (a
 
 type Post struct {
 	BaseContrib2
-	Content []byte `json:"content"`
+	Content []byte    `json:"content"`
+	Tip     sdk.Coins `json:"tip"`
+}
+
+func (ctb Post) ValidateBasic() sdk.Error {
+	if err := ctb.BaseContrib2.ValidateBasic(); err != nil {
+		return err
+	}
+	if err := validateTip(ctb.Tip); err != nil {
+		return err
+	}
+	return validateContent(ctb.Content, MaxPostContentSize)
 }
 
+func (ctb Post) GetTip() sdk.Coins { return ctb.Tip }
+
 func (ctb Post) NewStatus() Status {
 	return &PostStatus{BaseStatus: BaseStatus{Score: 1, Contributor: ctb.Contributor, Time: ctb.Time}, Recipient: ctb.Recipient}
 }
@@ -194,6 +256,8 @@ type BaseContrib3 struct {
 	Vote      int64          `json:"vote"`
 }
 
+func (ctb BaseContrib3) GetRecipient() sdk.AccAddress { return ctb.Recipient }
+
 func (ctb BaseContrib3) AppendTags(tags *sdk.Tags) {
 	*tags = append(*tags, sdk.MakeTag("contributor", ctb.Contributor.Bytes()), sdk.MakeTag("recipient", ctb.Recipient.Bytes()))
 }
@@ -203,11 +267,8 @@ func (ctb BaseContrib3) NewStatus() Status {
 }
 
 func (ctb BaseContrib3) ValidateBasic() sdk.Error {
-	if len(ctb.Key) == 0 {
-		return ErrInvalidContrib(DefaultCodespace, ctb.String())
-	}
-	if len(ctb.Contributor) == 0 {
-		return sdk.ErrInvalidAddress(ctb.Contributor.String())
+	if err := ctb.BaseContrib.ValidateBasic(); err != nil {
+		return err
 	}
 	if len(ctb.Recipient) == 0 {
 		return sdk.ErrInvalidAddress(ctb.Recipient.String())
@@ -233,6 +294,13 @@ type Vote struct {
 	Content []byte `json:"content"`
 }
 
+func (ctb Vote) ValidateBasic() sdk.Error {
+	if err := ctb.BaseContrib3.ValidateBasic(); err != nil {
+		return err
+	}
+	return validateContent(ctb.Content, MaxVoteContentSize)
+}
+
 //new status of vote always start from 1, showing difference between up and down will be in update()
 func (ctb Vote) NewStatus() Status {
 	return &VoteStatus{BaseStatus: BaseStatus{Score: 1, Contributor: ctb.Contributor, Time: ctb.Time}, Recipient: ctb.Recipient, Vote: ctb.Vote}
@@ -276,6 +344,7 @@ func (status *BaseStatus) Update(ctb Contrib) sdk.Error {
 type BaseStatus2 struct {
 	BaseStatus
 	Recipient sdk.AccAddress `json:"recipient"`
+	TipTotal  sdk.Coins      `json:"tip_total,omitempty"`
 }
 
 func (status *BaseStatus2) Update(ctb Contrib) sdk.Error {
@@ -301,7 +370,10 @@ func (status *BaseStatus2) Update(ctb Contrib) sdk.Error {
 type InviteStatus BaseStatus2
 
 func (status *InviteStatus) Update(ctb Contrib) sdk.Error {
-	ctb2 := ctb.(*Invite)
+	ctb2, ok := ctb.(*Invite)
+	if !ok {
+		return ErrInvalidContrib(DefaultCodespace, "key used by another contrib type")
+	}
 	// check if addr is the contributor
 	if !bytes.Equal(ctb2.Contributor, status.Contributor) {
 		return sdk.ErrUnknownAddress("contributor error")
@@ -321,14 +393,23 @@ func (status *InviteStatus) Update(ctb Contrib) sdk.Error {
 
 type RecommendStatus BaseStatus2
 
+func (status *RecommendStatus) AddTip(tip sdk.Coins) {
+	status.TipTotal = status.TipTotal.Plus(tip)
+}
+
 type PostStatus BaseStatus2
 
+func (status *PostStatus) AddTip(tip sdk.Coins) {
+	status.TipTotal = status.TipTotal.Plus(tip)
+}
+
 //update() will be using the BaseStatus2's update()
 
 type BaseStatus3 struct {
 	BaseStatus
 	Recipient sdk.AccAddress `json:"recipient"`
 	Vote      int64          `json:"vote"`
+	Weight    int64          `json:"weight"` // voting weight the vote is tallied with
 }
 
 func (status *BaseStatus3) Update(ctb Contrib) sdk.Error {
@@ -354,7 +435,10 @@ func (status *BaseStatus3) Update(ctb Contrib) sdk.Error {
 type VoteStatus BaseStatus3
 
 func (status *VoteStatus) Update(ctb Contrib) sdk.Error {
-	ctb2 := ctb.(*Vote)
+	ctb2, ok := ctb.(*Vote)
+	if !ok {
+		return ErrInvalidContrib(DefaultCodespace, "key used by another contrib type")
+	}
 	// check if addr is the contributor
 	if !bytes.Equal(ctb2.Contributor, status.Contributor) {
 		return sdk.ErrUnknownAddress("contributor error")
@@ -0,0 +1,59 @@
+package contrib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+func TestContentSizeLimits(t *testing.T) {
+	base2 := BaseContrib2{BaseContrib{[]byte("key"), addr1, genesisTime}, addr2}
+	base3 := BaseContrib3{BaseContrib{[]byte("key"), addr1, genesisTime}, addr2, 1}
+
+	cases := []struct {
+		name    string
+		maxSize int
+		ctb     func(content []byte) Contrib
+	}{
+		{"invite", MaxInviteContentSize, func(content []byte) Contrib { return Invite{base2, content} }},
+		{"recommend", MaxRecommendContentSize, func(content []byte) Contrib { return Recommend{base2, content, nil} }},
+		{"post", MaxPostContentSize, func(content []byte) Contrib { return Post{base2, content, nil} }},
+		{"vote", MaxVoteContentSize, func(content []byte) Contrib { return Vote{base3, content} }},
+	}
+	for _, tc := range cases {
+		require.Nil(t, tc.ctb(nil).ValidateBasic(), tc.name)
+		require.Nil(t, tc.ctb(make([]byte, tc.maxSize)).ValidateBasic(), tc.name)
+
+		err := tc.ctb(make([]byte, tc.maxSize+1)).ValidateBasic()
+		require.NotNil(t, err, tc.name)
+		require.Equal(t, CodeContentTooLarge, err.Code(), tc.name)
+	}
+}
+
+func TestContribsValidateBasic(t *testing.T) {
+	post := newPost("post", addr1, addr2, genesisTime)
+	vote := newVote("vote", addr1, addr2, genesisTime, 1)
+	samePost := newPost("post", addr2, addr1, genesisTime)
+	sameVote := newVote("post", addr1, addr2, genesisTime, 1)
+
+	cases := []struct {
+		name     string
+		contribs Contribs
+		code     sdk.CodeType
+	}{
+		{"distinct keys", Contribs{post, vote}, sdk.CodeOK},
+		{"duplicate key", Contribs{post, vote, samePost}, CodeInvalidContrib},
+		{"duplicate key of another type", Contribs{post, sameVote}, CodeInvalidContrib},
+	}
+	for _, tc := range cases {
+		err := tc.contribs.ValidateBasic()
+		if tc.code == sdk.CodeOK {
+			require.Nil(t, err, tc.name)
+		} else {
+			require.NotNil(t, err, tc.name)
+			require.Equal(t, tc.code, err.Code(), tc.name)
+		}
+	}
+}
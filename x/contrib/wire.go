@@ -9,14 +9,14 @@ import (
 // Register concrete types on wire codec
 func RegisterWire(cdc *wire.Codec) {
 	cdc.RegisterInterface((*Contrib)(nil), nil)
-	cdc.RegisterConcrete(&Invite{}, "contrib/Invite", nil)
-	cdc.RegisterConcrete(&Recommend{}, "contrib/Recommend", nil)
-	cdc.RegisterConcrete(&Vote{}, "contrib/Vote", nil)
-	cdc.RegisterConcrete(&Post{}, "contrib/Post", nil)
+	registerContribTypes(cdc)
 	cdc.RegisterInterface((*Status)(nil), nil)
-	cdc.RegisterConcrete(&InviteStatus{}, "contrib/InviteStatus", nil)
-	cdc.RegisterConcrete(&RecommendStatus{}, "contrib/RecommendStatus", nil)
-	cdc.RegisterConcrete(&VoteStatus{}, "contrib/VoteStatus", nil)
-	cdc.RegisterConcrete(&PostStatus{}, "contrib/PostStatus", nil)
+	registerStatusTypes(cdc)
 	cdc.RegisterConcrete(MsgContrib{}, "forbole/ContribMsg", nil)
+	cdc.RegisterConcrete(MsgDelegateRepute{}, "forbole/MsgDelegateRepute", nil)
+	cdc.RegisterConcrete(MsgUndelegateRepute{}, "forbole/MsgUndelegateRepute", nil)
+	cdc.RegisterConcrete(MsgCreateBounty{}, "forbole/MsgCreateBounty", nil)
+	cdc.RegisterConcrete(MsgSubmitBounty{}, "forbole/MsgSubmitBounty", nil)
+	cdc.RegisterConcrete(MsgAwardBounty{}, "forbole/MsgAwardBounty", nil)
+	cdc.RegisterConcrete(MsgSetProfile{}, "forbole/MsgSetProfile", nil)
 }
@@ -0,0 +1,34 @@
+package sponsor
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+)
+
+// NewAnteHandler wraps the standard ante handler so that the fee of a tx
+// whose fee payer (the first signer) holds a grant is paid by the granter.
+// The fee is moved to the fee payer before the wrapped handler deducts it,
+// and nothing is written unless the wrapped handler accepts the tx. If the
+// grant cannot cover the fee the fee payer pays it as usual.
+func NewAnteHandler(k Keeper, ante sdk.AnteHandler) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx) (newCtx sdk.Context, res sdk.Result, abort bool) {
+		stdTx, ok := tx.(auth.StdTx)
+		if !ok || stdTx.Fee.Amount.IsZero() || len(stdTx.GetSigners()) == 0 {
+			return ante(ctx, tx)
+		}
+
+		cacheCtx, writeCache := ctx.CacheContext()
+
+		used, err := k.UseGrant(cacheCtx, stdTx.GetSigners()[0], stdTx.Fee.Amount)
+		if !used || err != nil {
+			return ante(ctx, tx)
+		}
+
+		newCtx, res, abort = ante(cacheCtx, tx)
+		if abort {
+			return newCtx, res, abort
+		}
+		writeCache()
+		return newCtx, res, abort
+	}
+}
@@ -0,0 +1,66 @@
+package sponsor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+)
+
+// feeAnte stands for the standard ante handler, it deducts the fee from the
+// fee payer and then aborts if told to
+func feeAnte(ck bank.Keeper, abort bool) sdk.AnteHandler {
+	return func(ctx sdk.Context, tx sdk.Tx) (sdk.Context, sdk.Result, bool) {
+		stdTx := tx.(auth.StdTx)
+		_, _, err := ck.SubtractCoins(ctx, stdTx.GetSigners()[0], stdTx.Fee.Amount)
+		if err != nil {
+			return ctx, err.Result(), true
+		}
+		if abort {
+			return ctx, sdk.ErrUnauthorized("signature verification failed").Result(), true
+		}
+		return ctx, sdk.Result{}, false
+	}
+}
+
+// newTx returns a tx whose fee payer is payer
+func newTx(payer sdk.AccAddress, fee int64) auth.StdTx {
+	msg := NewMsgRevokeFee(payer, sponsor2)
+	return auth.NewStdTx([]sdk.Msg{msg}, auth.NewStdFee(1000, fbc(fee)...), nil, "")
+}
+
+func TestAnteHandler(t *testing.T) {
+	ctx, am, k := createTestInput(t)
+	require.Nil(t, k.GrantFee(ctx, sponsor1, invitee, fbc(10), false))
+	acc := am.GetAccount(ctx, invitee)
+	acc.SetCoins(fbc(20))
+	am.SetAccount(ctx, acc)
+
+	steps := []struct {
+		name      string
+		fee       int64
+		abort     bool
+		allowance int64
+		sponsor   int64
+		invitee   int64
+	}{
+		{"granter pays", 4, false, 6, 96, 20},
+		{"aborted tx uses nothing", 4, true, 6, 96, 20},
+		{"allowance exceeded, the payer pays", 7, false, 6, 96, 13},
+		{"allowance used up", 6, false, 0, 90, 13},
+		{"no grant, the payer pays", 3, false, 0, 90, 10},
+	}
+	for _, step := range steps {
+		_, _, abort := NewAnteHandler(k, feeAnte(k.ck, step.abort))(ctx, newTx(invitee, step.fee))
+		require.Equal(t, step.abort, abort, step.name)
+
+		grant, found := k.GetGrant(ctx, invitee)
+		require.Equal(t, step.allowance != 0, found, step.name)
+		require.Equal(t, step.allowance, grant.Allowance.AmountOf("fbc").Int64(), step.name)
+		require.Equal(t, step.sponsor, getBalance(ctx, am, sponsor1), step.name)
+		require.Equal(t, step.invitee, getBalance(ctx, am, invitee), step.name)
+	}
+}
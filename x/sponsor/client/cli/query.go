@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/wire"
+
+	"github.com/forbole/forboled/x/contrib/client"
+	"github.com/forbole/forboled/x/sponsor"
+)
+
+// GetGrantCmd returns a query command that will display the fee grant of
+// an account
+func GetGrantCmd(storeName string, cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "query [grantee]",
+		Short: "Query the fee grant of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cliCtx := context.NewCLIContext().WithCodec(cdc)
+			grantee, err := client.ResolveAddress(cliCtx, client.StoreName, args[0])
+			if err != nil {
+				return err
+			}
+
+			res, err := cliCtx.QueryStore(sponsor.GetGrantKey(grantee), storeName)
+			if err != nil {
+				return err
+			}
+			if len(res) == 0 {
+				return fmt.Errorf("no fee grant for %s", grantee)
+			}
+
+			var grant sponsor.Grant
+			err = cdc.UnmarshalBinary(res, &grant)
+			if err != nil {
+				return err
+			}
+
+			output, err := wire.MarshalJSONIndent(cdc, grant)
+			if err != nil {
+				return err
+			}
+			fmt.Println(string(output))
+			return nil
+		},
+	}
+	return cmd
+}
+
+// GetCommunityPoolCmd prints the address of the community pool so it can be
+// funded with a bank send
+func GetCommunityPoolCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "community-pool",
+		Short: "Show the address of the community pool paying community grants",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			fmt.Println(sponsor.CommunityPoolAddr)
+			return nil
+		},
+	}
+}
@@ -0,0 +1,87 @@
+package cli
+
+import (
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	authctx "github.com/cosmos/cosmos-sdk/x/auth/client/context"
+
+	"github.com/forbole/forboled/client/utils"
+	"github.com/forbole/forboled/types"
+	"github.com/forbole/forboled/x/contrib/client"
+	"github.com/forbole/forboled/x/sponsor"
+)
+
+const flagCommunity = "community"
+
+// GrantFeeTxCmd will create a fee grant tx and sign it with the given key
+func GrantFeeTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "grant [grantee] [allowance]",
+		Short: "Pay the tx fees of an account up to an allowance",
+		Args:  cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			grantee, err := client.ResolveAddress(cliCtx, client.StoreName, args[0])
+			if err != nil {
+				return err
+			}
+
+			allowance, err := sdk.ParseCoins(args[1])
+			if err != nil {
+				return err
+			}
+
+			msg := sponsor.NewMsgGrantFee(from, grantee, allowance, viper.GetBool(flagCommunity))
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+
+	cmd.Flags().Bool(flagCommunity, false, "Pay the fees from the community pool (admins only)")
+	return cmd
+}
+
+// RevokeFeeTxCmd will create a fee revoke tx and sign it with the given key
+func RevokeFeeTxCmd(cdc *wire.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "revoke [grantee]",
+		Short: "Stop paying the tx fees of an account",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			txCtx := authctx.NewTxContextFromCLI().WithCodec(cdc)
+			cliCtx := context.NewCLIContext().
+				WithCodec(cdc).
+				WithLogger(os.Stdout).
+				WithAccountDecoder(types.GetReputeAccountDecoder(cdc))
+
+			from, err := utils.GetFromAddress(cliCtx)
+			if err != nil {
+				return err
+			}
+
+			grantee, err := client.ResolveAddress(cliCtx, client.StoreName, args[0])
+			if err != nil {
+				return err
+			}
+
+			msg := sponsor.NewMsgRevokeFee(from, grantee)
+			return utils.SendTx(txCtx, cliCtx, []sdk.Msg{msg})
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,65 @@
+//nolint
+package sponsor
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// Sponsor errors reserve 1000 ~ 1099.
+const (
+	DefaultCodespace sdk.CodespaceType = 11
+
+	CodeInvalidGrant      sdk.CodeType = 1001
+	CodeGrantNotFound     sdk.CodeType = 1002
+	CodeUnauthorized      sdk.CodeType = 1003
+	CodeAllowanceExceeded sdk.CodeType = 1004
+)
+
+// NOTE: Don't stringer this, we'll put better messages in later.
+func codeToDefaultMsg(code sdk.CodeType) string {
+	switch code {
+	case CodeInvalidGrant:
+		return "Invalid fee grant"
+	case CodeGrantNotFound:
+		return "Fee grant not found"
+	case CodeUnauthorized:
+		return "Not allowed to manage this fee grant"
+	case CodeAllowanceExceeded:
+		return "Fee exceeds the granted allowance"
+	default:
+		return sdk.CodeToDefaultMsg(code)
+	}
+}
+
+//----------------------------------------
+// Error constructors
+
+func ErrInvalidGrant(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeInvalidGrant, msg)
+}
+
+func ErrGrantNotFound(codespace sdk.CodespaceType, grantee sdk.AccAddress) sdk.Error {
+	return newError(codespace, CodeGrantNotFound, "no fee grant for "+grantee.String())
+}
+
+func ErrUnauthorized(codespace sdk.CodespaceType, msg string) sdk.Error {
+	return newError(codespace, CodeUnauthorized, msg)
+}
+
+func ErrAllowanceExceeded(codespace sdk.CodespaceType, fee, allowance sdk.Coins) sdk.Error {
+	return newError(codespace, CodeAllowanceExceeded, "fee "+fee.String()+" exceeds allowance "+allowance.String())
+}
+
+//----------------------------------------
+
+func msgOrDefaultMsg(msg string, code sdk.CodeType) string {
+	if msg != "" {
+		return msg
+	}
+	return codeToDefaultMsg(code)
+}
+
+func newError(codespace sdk.CodespaceType, code sdk.CodeType, msg string) sdk.Error {
+	msg = msgOrDefaultMsg(msg, code)
+	return sdk.NewError(codespace, code, msg)
+}
@@ -0,0 +1,32 @@
+package sponsor
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// GenesisState - the fee grants of the sponsor module
+type GenesisState struct {
+	Grants []Grant `json:"grants"`
+}
+
+// DefaultGenesisState - a chain starts without grants
+func DefaultGenesisState() GenesisState {
+	return GenesisState{}
+}
+
+// InitGenesis stores the grants
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) {
+	for _, grant := range data.Grants {
+		k.SetGrant(ctx, grant)
+	}
+}
+
+// WriteGenesis returns the grants in grantee order
+func WriteGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	var grants []Grant
+	k.IterateGrants(ctx, func(grant Grant) bool {
+		grants = append(grants, grant)
+		return false
+	})
+	return GenesisState{Grants: grants}
+}
@@ -0,0 +1,52 @@
+package sponsor
+
+import (
+	"reflect"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// NewHandler returns a handler for "sponsor" type messages.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) sdk.Result {
+		switch msg := msg.(type) {
+		case MsgGrantFee:
+			return handleMsgGrantFee(ctx, k, msg)
+		case MsgRevokeFee:
+			return handleMsgRevokeFee(ctx, k, msg)
+		default:
+			errMsg := "Unrecognized sponsor Msg type: " + reflect.TypeOf(msg).Name()
+			return sdk.ErrUnknownRequest(errMsg).Result()
+		}
+	}
+}
+
+// Handle MsgGrantFee.
+func handleMsgGrantFee(ctx sdk.Context, k Keeper, msg MsgGrantFee) sdk.Result {
+	err := k.GrantFee(ctx, msg.Granter, msg.Grantee, msg.Allowance, msg.Community)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"granter", msg.Granter.Bytes(),
+			"grantee", msg.Grantee.Bytes(),
+		),
+	}
+}
+
+// Handle MsgRevokeFee.
+func handleMsgRevokeFee(ctx sdk.Context, k Keeper, msg MsgRevokeFee) sdk.Result {
+	err := k.RevokeFee(ctx, msg.Granter, msg.Grantee)
+	if err != nil {
+		return err.Result()
+	}
+
+	return sdk.Result{
+		Tags: sdk.NewTags(
+			"granter", msg.Granter.Bytes(),
+			"grantee", msg.Grantee.Bytes(),
+		),
+	}
+}
@@ -0,0 +1,128 @@
+package sponsor
+
+import (
+	"bytes"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+
+	"github.com/forbole/forboled/types"
+)
+
+// Key prefix of the grants, keyed by grantee
+var grantKeyPrefix = []byte{0x01}
+
+// GetGrantKey returns the store key of the grant of a grantee
+func GetGrantKey(grantee sdk.AccAddress) []byte {
+	return append(grantKeyPrefix, grantee.Bytes()...)
+}
+
+// Keeper manages fee grants
+type Keeper struct {
+	cdc       *wire.Codec
+	am        auth.AccountMapper
+	ck        bank.Keeper
+	storeKey  sdk.StoreKey
+	codespace sdk.CodespaceType
+}
+
+// NewKeeper returns a new Keeper
+func NewKeeper(cdc *wire.Codec, am auth.AccountMapper, ck bank.Keeper, storeKey sdk.StoreKey, codespace sdk.CodespaceType) Keeper {
+	return Keeper{cdc: cdc, am: am, ck: ck, storeKey: storeKey, codespace: codespace}
+}
+
+// GetGrant returns the grant of a grantee
+func (k Keeper) GetGrant(ctx sdk.Context, grantee sdk.AccAddress) (grant Grant, found bool) {
+	bz := ctx.KVStore(k.storeKey).Get(GetGrantKey(grantee))
+	if bz == nil {
+		return grant, false
+	}
+	k.cdc.MustUnmarshalBinary(bz, &grant)
+	return grant, true
+}
+
+// SetGrant stores a grant, replacing any grant of the same grantee
+func (k Keeper) SetGrant(ctx sdk.Context, grant Grant) {
+	ctx.KVStore(k.storeKey).Set(GetGrantKey(grant.Grantee), k.cdc.MustMarshalBinary(grant))
+}
+
+// DeleteGrant removes the grant of a grantee
+func (k Keeper) DeleteGrant(ctx sdk.Context, grantee sdk.AccAddress) {
+	ctx.KVStore(k.storeKey).Delete(GetGrantKey(grantee))
+}
+
+// IterateGrants iterates over all the grants
+func (k Keeper) IterateGrants(ctx sdk.Context, process func(Grant) (stop bool)) {
+	iter := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), grantKeyPrefix)
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		var grant Grant
+		k.cdc.MustUnmarshalBinary(iter.Value(), &grant)
+		if process(grant) {
+			return
+		}
+	}
+}
+
+// GrantFee lets grantee spend up to allowance of granter's coins on fees
+func (k Keeper) GrantFee(ctx sdk.Context, granter, grantee sdk.AccAddress, allowance sdk.Coins, community bool) sdk.Error {
+	payer := granter
+	if community {
+		acc, _ := k.am.GetAccount(ctx, granter).(*types.ReputeAccount)
+		if !acc.IsAdmin() {
+			return ErrUnauthorized(k.codespace, "only admins can grant from the community pool")
+		}
+		payer = CommunityPoolAddr
+	}
+
+	// a sponsor can only take over an invitee from the community pool, not
+	// from another sponsor
+	if old, found := k.GetGrant(ctx, grantee); found && !bytes.Equal(old.Granter, payer) && !old.IsCommunity() {
+		return ErrUnauthorized(k.codespace, grantee.String()+" is already sponsored by "+old.Granter.String())
+	}
+
+	k.SetGrant(ctx, Grant{Granter: payer, Grantee: grantee, Allowance: allowance})
+	return nil
+}
+
+// RevokeFee removes a grant made by granter, or from the community pool if
+// granter is an admin
+func (k Keeper) RevokeFee(ctx sdk.Context, granter, grantee sdk.AccAddress) sdk.Error {
+	grant, found := k.GetGrant(ctx, grantee)
+	if !found {
+		return ErrGrantNotFound(k.codespace, grantee)
+	}
+	acc, _ := k.am.GetAccount(ctx, granter).(*types.ReputeAccount)
+	if !bytes.Equal(grant.Granter, granter) && !(grant.IsCommunity() && acc.IsAdmin()) {
+		return ErrUnauthorized(k.codespace, "grant was not made by "+granter.String())
+	}
+	k.DeleteGrant(ctx, grantee)
+	return nil
+}
+
+// UseGrant moves fee from the granter to the grantee so the standard ante
+// handler can deduct it, and lowers the allowance accordingly. It returns
+// false if the grantee has no grant.
+func (k Keeper) UseGrant(ctx sdk.Context, grantee sdk.AccAddress, fee sdk.Coins) (bool, sdk.Error) {
+	grant, found := k.GetGrant(ctx, grantee)
+	if !found {
+		return false, nil
+	}
+	if !grant.Allowance.IsGTE(fee) {
+		return true, ErrAllowanceExceeded(k.codespace, fee, grant.Allowance)
+	}
+
+	_, err := k.ck.SendCoins(ctx, grant.Granter, grantee, fee)
+	if err != nil {
+		return true, err
+	}
+
+	grant.Allowance = grant.Allowance.Minus(fee)
+	if grant.Allowance.IsZero() {
+		k.DeleteGrant(ctx, grantee)
+	} else {
+		k.SetGrant(ctx, grant)
+	}
+	return true, nil
+}
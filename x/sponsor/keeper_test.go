@@ -0,0 +1,184 @@
+package sponsor
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/cosmos/cosmos-sdk/store"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/wire"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/bank"
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+	dbm "github.com/tendermint/tendermint/libs/db"
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/forbole/forboled/types"
+)
+
+var (
+	sponsor1 = sdk.AccAddress(tmhash.Sum([]byte("sponsor1")))
+	sponsor2 = sdk.AccAddress(tmhash.Sum([]byte("sponsor2")))
+	invitee  = sdk.AccAddress(tmhash.Sum([]byte("invitee")))
+	admin    = sdk.AccAddress(tmhash.Sum([]byte("admin")))
+)
+
+func fbc(amount int64) sdk.Coins {
+	return sdk.Coins{sdk.NewInt64Coin("fbc", amount)}
+}
+
+// createTestInput returns a context over fresh acc and sponsor stores, with
+// an admin and two sponsors holding 100fbc and an invitee without coins
+func createTestInput(t *testing.T) (sdk.Context, auth.AccountMapper, Keeper) {
+	keyAcc := sdk.NewKVStoreKey("acc")
+	keySponsor := sdk.NewKVStoreKey("sponsor")
+
+	db := dbm.NewMemDB()
+	ms := store.NewCommitMultiStore(db)
+	ms.MountStoreWithDB(keyAcc, sdk.StoreTypeIAVL, db)
+	ms.MountStoreWithDB(keySponsor, sdk.StoreTypeIAVL, db)
+	require.Nil(t, ms.LoadLatestVersion())
+	ctx := sdk.NewContext(ms, abci.Header{Height: 1}, false, log.NewNopLogger())
+
+	cdc := wire.NewCodec()
+	RegisterWire(cdc)
+	wire.RegisterCrypto(cdc)
+	cdc.RegisterInterface((*auth.Account)(nil), nil)
+	cdc.RegisterConcrete(&types.ReputeAccount{}, "forbole/Repute", nil)
+
+	am := auth.NewAccountMapper(cdc, keyAcc, types.ProtoReputeAccount)
+	k := NewKeeper(cdc, am, bank.NewKeeper(am), keySponsor, DefaultCodespace)
+
+	for _, addr := range []sdk.AccAddress{sponsor1, sponsor2, admin, CommunityPoolAddr} {
+		acc := am.NewAccountWithAddress(ctx, addr).(*types.ReputeAccount)
+		acc.Coins = fbc(100)
+		if bytes.Equal(addr, admin) {
+			acc.Role = types.AdminRole
+		}
+		am.SetAccount(ctx, acc)
+	}
+	am.SetAccount(ctx, am.NewAccountWithAddress(ctx, invitee))
+	return ctx, am, k
+}
+
+func getBalance(ctx sdk.Context, am auth.AccountMapper, addr sdk.AccAddress) int64 {
+	return am.GetAccount(ctx, addr).GetCoins().AmountOf("fbc").Int64()
+}
+
+func requireCode(t *testing.T, code sdk.CodeType, err sdk.Error, name string) {
+	if code == sdk.CodeOK {
+		require.Nil(t, err, name)
+	} else {
+		require.NotNil(t, err, name)
+		require.Equal(t, code, err.Code(), name)
+	}
+}
+
+func TestGrantFee(t *testing.T) {
+	ctx, _, k := createTestInput(t)
+
+	cases := []struct {
+		name      string
+		granter   sdk.AccAddress
+		grantee   sdk.AccAddress
+		community bool
+		code      sdk.CodeType
+		payer     sdk.AccAddress
+	}{
+		{"community grant by a sponsor", sponsor1, invitee, true, CodeUnauthorized, nil},
+		{"community grant by an admin", admin, invitee, true, sdk.CodeOK, CommunityPoolAddr},
+		{"sponsor takes over a community grant", sponsor1, invitee, false, sdk.CodeOK, sponsor1},
+		{"sponsor raises its grant", sponsor1, invitee, false, sdk.CodeOK, sponsor1},
+		{"another sponsor", sponsor2, invitee, false, CodeUnauthorized, sponsor1},
+		{"community grant over a sponsor", admin, invitee, true, CodeUnauthorized, sponsor1},
+	}
+	for _, tc := range cases {
+		err := k.GrantFee(ctx, tc.granter, tc.grantee, fbc(10), tc.community)
+		requireCode(t, tc.code, err, tc.name)
+
+		grant, found := k.GetGrant(ctx, tc.grantee)
+		require.Equal(t, tc.payer != nil, found, tc.name)
+		require.Equal(t, tc.payer, grant.Granter, tc.name)
+	}
+}
+
+func TestRevokeFee(t *testing.T) {
+	ctx, _, k := createTestInput(t)
+	require.Nil(t, k.GrantFee(ctx, admin, invitee, fbc(10), true))
+	require.Nil(t, k.GrantFee(ctx, sponsor1, sponsor2, fbc(10), false))
+
+	cases := []struct {
+		name    string
+		granter sdk.AccAddress
+		grantee sdk.AccAddress
+		code    sdk.CodeType
+	}{
+		{"community grant by a sponsor", sponsor1, invitee, CodeUnauthorized},
+		{"community grant by an admin", admin, invitee, sdk.CodeOK},
+		{"revoked grant", admin, invitee, CodeGrantNotFound},
+		{"grant of another sponsor", admin, sponsor2, CodeUnauthorized},
+		{"own grant", sponsor1, sponsor2, sdk.CodeOK},
+	}
+	for _, tc := range cases {
+		err := k.RevokeFee(ctx, tc.granter, tc.grantee)
+		requireCode(t, tc.code, err, tc.name)
+	}
+	_, found := k.GetGrant(ctx, invitee)
+	require.False(t, found)
+	_, found = k.GetGrant(ctx, sponsor2)
+	require.False(t, found)
+}
+
+func TestUseGrant(t *testing.T) {
+	ctx, am, k := createTestInput(t)
+
+	_, err := k.UseGrant(ctx, invitee, fbc(1))
+	require.Nil(t, err)
+	require.Nil(t, k.GrantFee(ctx, sponsor1, invitee, fbc(10), false))
+
+	steps := []struct {
+		name      string
+		fee       int64
+		code      sdk.CodeType
+		allowance int64
+		balance   int64
+	}{
+		{"part of the allowance", 4, sdk.CodeOK, 6, 96},
+		{"allowance exceeded", 7, CodeAllowanceExceeded, 6, 96},
+		{"rest of the allowance", 6, sdk.CodeOK, 0, 90},
+	}
+	for _, step := range steps {
+		used, err := k.UseGrant(ctx, invitee, fbc(step.fee))
+		require.True(t, used, step.name)
+		requireCode(t, step.code, err, step.name)
+
+		grant, found := k.GetGrant(ctx, invitee)
+		require.Equal(t, step.allowance != 0, found, step.name)
+		require.Equal(t, step.allowance, grant.Allowance.AmountOf("fbc").Int64(), step.name)
+		require.Equal(t, step.balance, getBalance(ctx, am, sponsor1), step.name)
+		require.Equal(t, 100-step.balance, getBalance(ctx, am, invitee), step.name)
+	}
+
+	// the used up grant was deleted
+	used, err := k.UseGrant(ctx, invitee, fbc(1))
+	require.False(t, used)
+	require.Nil(t, err)
+}
+
+func TestGenesis(t *testing.T) {
+	ctx, _, k := createTestInput(t)
+	require.Nil(t, k.GrantFee(ctx, admin, invitee, fbc(10), true))
+	require.Nil(t, k.GrantFee(ctx, sponsor1, sponsor2, fbc(20), false))
+	data := WriteGenesis(ctx, k)
+	require.Len(t, data.Grants, 2)
+
+	ctx2, _, k2 := createTestInput(t)
+	InitGenesis(ctx2, k2, data)
+	require.Equal(t, data, WriteGenesis(ctx2, k2))
+	grant, found := k2.GetGrant(ctx2, invitee)
+	require.True(t, found)
+	require.True(t, grant.IsCommunity())
+}
@@ -0,0 +1,100 @@
+package sponsor
+
+import (
+	"bytes"
+	"encoding/json"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// MsgGrantFee - grant a fee allowance to an account, replacing any existing
+// grant of the grantee
+type MsgGrantFee struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance sdk.Coins      `json:"allowance"`
+	Community bool           `json:"community"`
+}
+
+var _ sdk.Msg = MsgGrantFee{}
+
+// NewMsgGrantFee - construct a fee grant msg. If community is set the fees
+// are paid by the community pool and the granter must be an admin.
+func NewMsgGrantFee(granter, grantee sdk.AccAddress, allowance sdk.Coins, community bool) MsgGrantFee {
+	return MsgGrantFee{Granter: granter, Grantee: grantee, Allowance: allowance, Community: community}
+}
+
+// Implements Msg.
+func (msg MsgGrantFee) Type() string { return "sponsor" }
+
+// Implements Msg.
+func (msg MsgGrantFee) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	if bytes.Equal(msg.Granter, msg.Grantee) {
+		return ErrInvalidGrant(DefaultCodespace, "cannot grant to yourself")
+	}
+	if !msg.Allowance.IsValid() || !msg.Allowance.IsPositive() {
+		return ErrInvalidGrant(DefaultCodespace, "allowance must be positive: "+msg.Allowance.String())
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgGrantFee) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgGrantFee) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
+
+// MsgRevokeFee - remove the fee grant of an account
+type MsgRevokeFee struct {
+	Granter sdk.AccAddress `json:"granter"`
+	Grantee sdk.AccAddress `json:"grantee"`
+}
+
+var _ sdk.Msg = MsgRevokeFee{}
+
+// NewMsgRevokeFee - construct a fee revoke msg
+func NewMsgRevokeFee(granter, grantee sdk.AccAddress) MsgRevokeFee {
+	return MsgRevokeFee{Granter: granter, Grantee: grantee}
+}
+
+// Implements Msg.
+func (msg MsgRevokeFee) Type() string { return "sponsor" }
+
+// Implements Msg.
+func (msg MsgRevokeFee) ValidateBasic() sdk.Error {
+	if len(msg.Granter) == 0 {
+		return sdk.ErrInvalidAddress(msg.Granter.String())
+	}
+	if len(msg.Grantee) == 0 {
+		return sdk.ErrInvalidAddress(msg.Grantee.String())
+	}
+	return nil
+}
+
+// Implements Msg.
+func (msg MsgRevokeFee) GetSignBytes() []byte {
+	b, err := json.Marshal(msg)
+	if err != nil {
+		panic(err)
+	}
+	return sdk.MustSortJSON(b)
+}
+
+// Implements Msg.
+func (msg MsgRevokeFee) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Granter}
+}
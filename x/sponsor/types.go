@@ -0,0 +1,30 @@
+package sponsor
+
+import (
+	"bytes"
+	"fmt"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/tendermint/tendermint/crypto/tmhash"
+)
+
+// CommunityPoolAddr is the module account paying the fees of grants made on
+// behalf of the community. Anyone can fund it with a plain bank send, only
+// admins can grant from it.
+var CommunityPoolAddr = sdk.AccAddress(tmhash.Sum([]byte("sponsor/community")))
+
+// Grant lets Grantee spend up to Allowance of Granter's coins on tx fees
+type Grant struct {
+	Granter   sdk.AccAddress `json:"granter"`
+	Grantee   sdk.AccAddress `json:"grantee"`
+	Allowance sdk.Coins      `json:"allowance"`
+}
+
+func (g Grant) String() string {
+	return fmt.Sprintf("Grant{%v -> %v %v}", g.Granter, g.Grantee, g.Allowance)
+}
+
+// IsCommunity returns true if the fees are paid by the community pool
+func (g Grant) IsCommunity() bool {
+	return bytes.Equal(g.Granter, CommunityPoolAddr)
+}
@@ -0,0 +1,11 @@
+package sponsor
+
+import (
+	"github.com/cosmos/cosmos-sdk/wire"
+)
+
+// Register concrete types on wire codec
+func RegisterWire(cdc *wire.Codec) {
+	cdc.RegisterConcrete(MsgGrantFee{}, "forbole/MsgGrantFee", nil)
+	cdc.RegisterConcrete(MsgRevokeFee{}, "forbole/MsgRevokeFee", nil)
+}